@@ -13,6 +13,8 @@ import (
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"thinkbank/backend/biz/pkg/secrets"
 )
 
 var Client *minio.Client
@@ -37,6 +39,18 @@ func DefaultConfig() *Config {
 	}
 }
 
+// ConfigFromSecrets returns DefaultConfig with the password overridden from an
+// encrypted secrets.Store, when one was loaded (see pkg/secrets).
+func ConfigFromSecrets(store *secrets.Store) *Config {
+	cfg := DefaultConfig()
+	if store != nil {
+		if pw := store.MinIOPassword(); pw != "" {
+			cfg.Password = pw
+		}
+	}
+	return cfg
+}
+
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -153,6 +167,68 @@ func GetClient() *minio.Client {
 	return Client
 }
 
+// ScopedCredentials are short-lived, per-user MinIO credentials (e.g. from pkg/sts).
+type ScopedCredentials struct {
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+}
+
+// NewScopedClient builds a MinIO client signed with temporary, caller-scoped credentials
+// instead of the root credentials, so handlers can mint presigned URLs on a caller's behalf.
+func NewScopedClient(endpoint string, secure bool, creds ScopedCredentials) (*minio.Client, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(creds.AccessKey, creds.SecretKey, creds.SessionToken),
+		Secure: secure,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scoped MinIO client: %w", err)
+	}
+	return client, nil
+}
+
+// core returns the low-level MinIO core client used for multipart operations.
+func core() *minio.Core {
+	return &minio.Core{Client: Client}
+}
+
+// InitiateMultipart starts a new multipart upload and returns the MinIO-assigned upload ID.
+func InitiateMultipart(ctx context.Context, bucket, objectName, contentType string) (string, error) {
+	uploadID, err := core().NewMultipartUpload(ctx, bucket, objectName, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to initiate multipart upload: %w", err)
+	}
+	return uploadID, nil
+}
+
+// UploadPart uploads a single part of a multipart upload and returns its ETag.
+func UploadPart(ctx context.Context, bucket, objectName, uploadID string, partNumber int, reader io.Reader, size int64) (string, error) {
+	part, err := core().PutObjectPart(ctx, bucket, objectName, uploadID, partNumber, reader, size, minio.PutObjectPartOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+	}
+	return part.ETag, nil
+}
+
+// CompleteMultipart finishes a multipart upload by stitching together the uploaded parts.
+func CompleteMultipart(ctx context.Context, bucket, objectName, uploadID string, parts []minio.CompletePart) error {
+	_, err := core().CompleteMultipartUpload(ctx, bucket, objectName, uploadID, parts, minio.PutObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return nil
+}
+
+// AbortMultipart cancels an in-progress multipart upload and releases its parts.
+func AbortMultipart(ctx context.Context, bucket, objectName, uploadID string) error {
+	if err := core().AbortMultipartUpload(ctx, bucket, objectName, uploadID); err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+	return nil
+}
+
 // GetBucket returns the default bucket name
 func GetBucket() string {
 	if strings.TrimSpace(defaultBucket) == "" {
@@ -12,6 +12,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"thinkbank/backend/biz/model"
+	"thinkbank/backend/biz/pkg/secrets"
 )
 
 var DB *gorm.DB
@@ -37,6 +38,18 @@ func DefaultConfig() *Config {
 	}
 }
 
+// ConfigFromSecrets returns DefaultConfig with the password overridden from an
+// encrypted secrets.Store, when one was loaded (see pkg/secrets).
+func ConfigFromSecrets(store *secrets.Store) *Config {
+	cfg := DefaultConfig()
+	if store != nil {
+		if pw := store.DBPassword(); pw != "" {
+			cfg.Password = pw
+		}
+	}
+	return cfg
+}
+
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -131,7 +144,7 @@ func migrateSchema(db *gorm.DB) error {
 	}
 
 	// Keep core tables in sync with model definitions (including deleted_at).
-	if err := db.AutoMigrate(&model.Asset{}, &model.ProcessingTask{}); err != nil {
+	if err := db.AutoMigrate(&model.Asset{}, &model.ProcessingTask{}, &model.AssetUpload{}); err != nil {
 		return err
 	}
 
@@ -178,6 +191,18 @@ func migrateSchema(db *gorm.DB) error {
 		}
 	}
 
+	// Generated tsvector column backing full-text search, fused with the vector
+	// retrievers in pkg/search via Reciprocal Rank Fusion.
+	if err := db.Exec(`
+		ALTER TABLE assets ADD COLUMN IF NOT EXISTS search_tsv tsvector
+			GENERATED ALWAYS AS (to_tsvector('simple', coalesce(caption, '') || ' ' || coalesce(content_text, ''))) STORED
+	`).Error; err != nil {
+		return err
+	}
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_assets_search_tsv ON assets USING gin (search_tsv)`).Error; err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -8,25 +8,41 @@ import (
 	"strconv"
 
 	"github.com/redis/go-redis/v9"
+
+	"thinkbank/backend/biz/pkg/secrets"
 )
 
 var Client *redis.Client
 var queueName string
+var streamCfg *Config
 
 type Config struct {
 	Host      string
 	Port      int
+	Password  string
 	DB        int
 	QueueName string
+
+	// Stream, ConsumerGroup, DLQStream and MaxDeliveries configure the reliable
+	// delivery queue (see queue.go).
+	Stream        string
+	ConsumerGroup string
+	DLQStream     string
+	MaxDeliveries int64
 }
 
 // DefaultConfig returns configuration from environment variables
 func DefaultConfig() *Config {
 	return &Config{
-		Host:      getEnv("REDIS_HOST", "localhost"),
-		Port:      getEnvInt("REDIS_PORT", 6379),
-		DB:        0,
-		QueueName: getEnv("REDIS_QUEUE_NAME", "thinkbank:tasks"),
+		Host:          getEnv("REDIS_HOST", "localhost"),
+		Port:          getEnvInt("REDIS_PORT", 6379),
+		Password:      os.Getenv("REDIS_PASSWORD"),
+		DB:            0,
+		QueueName:     getEnv("REDIS_QUEUE_NAME", "thinkbank:tasks"),
+		Stream:        getEnv("REDIS_TASK_STREAM", "thinkbank:tasks:stream"),
+		ConsumerGroup: getEnv("REDIS_TASK_GROUP", "thinkbank-workers"),
+		DLQStream:     getEnv("REDIS_TASK_DLQ", "thinkbank:tasks:dlq"),
+		MaxDeliveries: int64(getEnvInt("REDIS_TASK_MAX_DELIVERIES", 5)),
 	}
 }
 
@@ -37,6 +53,18 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// ConfigFromSecrets returns DefaultConfig with the password overridden from an
+// encrypted secrets.Store, when one was loaded (see pkg/secrets).
+func ConfigFromSecrets(store *secrets.Store) *Config {
+	cfg := DefaultConfig()
+	if store != nil {
+		if pw := store.RedisPassword(); pw != "" {
+			cfg.Password = pw
+		}
+	}
+	return cfg
+}
+
 func getEnvInt(key string, fallback int) int {
 	raw := os.Getenv(key)
 	if raw == "" {
@@ -57,10 +85,12 @@ func Init(cfg *Config) error {
 	}
 
 	queueName = cfg.QueueName
+	streamCfg = cfg
 
 	Client = redis.NewClient(&redis.Options{
-		Addr: fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
-		DB:   cfg.DB,
+		Addr:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Password: cfg.Password,
+		DB:       cfg.DB,
 	})
 
 	ctx := context.Background()
@@ -68,6 +98,10 @@ func Init(cfg *Config) error {
 		return fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 
+	if err := ensureConsumerGroup(ctx, cfg.Stream, cfg.ConsumerGroup); err != nil {
+		return fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
 	return nil
 }
 
@@ -79,21 +113,28 @@ func Close() error {
 	return nil
 }
 
-// PushTask pushes an asset ID to the processing queue
+// PushTask pushes an asset ID to the processing queue.
+//
+// Deprecated: thin shim over Enqueue, kept so existing callers keep working
+// during the migration to the Streams-backed delivery queue in queue.go.
 func PushTask(ctx context.Context, assetID string) error {
-	return Client.LPush(ctx, getQueueName(), assetID).Err()
+	return Enqueue(ctx, Task{AssetID: assetID})
 }
 
-// PopTask pops an asset ID from the processing queue (blocking)
+// PopTask pops an asset ID from the processing queue (blocking).
+//
+// Deprecated: thin shim over Reserve that immediately Acks, emulating the old
+// fire-and-forget LPUSH/BRPOP semantics (no retry/visibility timeout). Prefer
+// Reserve/Ack/Nack directly for new callers.
 func PopTask(ctx context.Context) (string, error) {
-	result, err := Client.BRPop(ctx, 0, getQueueName()).Result()
+	delivery, err := Reserve(ctx, "legacy-consumer")
 	if err != nil {
 		return "", err
 	}
-	if len(result) < 2 {
-		return "", fmt.Errorf("no task received")
+	if err := Ack(ctx, delivery); err != nil {
+		return "", err
 	}
-	return result[1], nil
+	return delivery.Task.AssetID, nil
 }
 
 // GetClient returns the Redis client
@@ -0,0 +1,243 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+// Task is the unit of work pushed onto the processing queue.
+type Task struct {
+	AssetID string `json:"asset_id"`
+}
+
+// Delivery is a reserved, not-yet-acknowledged Task read from the stream.
+type Delivery struct {
+	StreamID string
+	Task     Task
+}
+
+var (
+	queueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "thinkbank_queue_depth",
+		Help: "Number of entries in the task stream, waiting or reserved but not yet acked.",
+	})
+	queueInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "thinkbank_queue_in_flight",
+		Help: "Number of tasks reserved but not yet acked or nacked.",
+	})
+	queueRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "thinkbank_queue_retries_total",
+		Help: "Number of times a task was reclaimed for retry.",
+	})
+	queueDLQSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "thinkbank_queue_dlq_size",
+		Help: "Number of tasks currently parked in the dead-letter stream.",
+	})
+)
+
+func cfg() *Config {
+	if streamCfg == nil {
+		streamCfg = DefaultConfig()
+	}
+	return streamCfg
+}
+
+func ensureConsumerGroup(ctx context.Context, stream, group string) error {
+	err := Client.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+	if err != nil && err.Error() != "BUSYGROUP Consumer Group name already exists" {
+		return err
+	}
+	return nil
+}
+
+// Enqueue adds a task to the stream for delivery to a consumer group worker.
+func Enqueue(ctx context.Context, task Task) error {
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	if err := Client.XAdd(ctx, &redis.XAddArgs{
+		Stream: cfg().Stream,
+		Values: map[string]interface{}{"task": string(payload), "deliveries": 0},
+	}).Err(); err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	if length, err := Client.XLen(ctx, cfg().Stream).Result(); err == nil {
+		queueDepth.Set(float64(length))
+	}
+	return nil
+}
+
+// Reserve reads the next task for consumer, making it invisible to other consumers
+// until it is Acked or reclaimed by Reap (Nack leaves it pending). Visibility timeout is a reaper-global
+// setting (Reap's own visibilityTimeout argument, via XAUTOCLAIM's MinIdle) rather than
+// something Reserve can vary per call — the stream has no notion of a per-message
+// timeout, only per-group idle time. Reserve checks the consumer's own pending-entries
+// list first, so messages Reap reclaimed for this consumer (a retry) are picked up
+// before any brand-new ">" entry — otherwise a reaped message is never actually
+// redelivered to anything.
+func Reserve(ctx context.Context, consumer string) (*Delivery, error) {
+	if delivery, err := readGroup(ctx, consumer, "0"); err == nil {
+		queueInFlight.Inc()
+		return delivery, nil
+	}
+
+	delivery, err := readGroup(ctx, consumer, ">")
+	if err != nil {
+		return nil, err
+	}
+
+	queueInFlight.Inc()
+	return delivery, nil
+}
+
+// readGroup runs a single XReadGroup call starting from start ("0" for the
+// consumer's own pending entries, ">" for new ones) and decodes the first message.
+func readGroup(ctx context.Context, consumer, start string) (*Delivery, error) {
+	streams, err := Client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    cfg().ConsumerGroup,
+		Consumer: consumer,
+		Streams:  []string{cfg().Stream, start},
+		Count:    1,
+		Block:    0,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve task: %w", err)
+	}
+	if len(streams) == 0 || len(streams[0].Messages) == 0 {
+		return nil, errors.New("no task available")
+	}
+
+	return deliveryFromMessage(streams[0].Messages[0])
+}
+
+// Ack confirms successful processing of a delivery, removing it from the stream and
+// the consumer group's pending-entries list.
+func Ack(ctx context.Context, d *Delivery) error {
+	pipe := Client.TxPipeline()
+	pipe.XAck(ctx, cfg().Stream, cfg().ConsumerGroup, d.StreamID)
+	pipe.XDel(ctx, cfg().Stream, d.StreamID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to ack task: %w", err)
+	}
+	queueInFlight.Dec()
+	return nil
+}
+
+// Nack reports that processing failed. The message stays in the consumer's
+// pending-entries list, so that consumer's next Reserve picks it up again, and Reap
+// can reassign it once its idle time exceeds Reap's visibilityTimeout. retryAfter is
+// not used: no delay is applied before the message is redelivered.
+func Nack(ctx context.Context, d *Delivery, retryAfter time.Duration) error {
+	queueInFlight.Dec()
+	// The message is left un-acked; only the in-flight gauge changes.
+	return nil
+}
+
+// Reap runs one pass of XAUTOCLAIM, reclaiming messages whose visibility timeout has
+// expired and reassigning them to consumer. Reassignment alone makes them reappear in
+// consumer's own pending-entries list, so a subsequent Reserve(ctx, consumer) call
+// picks them back up and actually retries them. Messages that have exceeded
+// maxDeliveries are moved to the DLQ stream instead, and their ProcessingTask is marked
+// FAILED by the caller.
+func Reap(ctx context.Context, consumer string, visibilityTimeout time.Duration, maxDeliveries int64, onDead func(ctx context.Context, d *Delivery, lastErr string) error) error {
+	cursor := "0-0"
+	for {
+		claimed, next, err := Client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   cfg().Stream,
+			Group:    cfg().ConsumerGroup,
+			Consumer: consumer,
+			MinIdle:  visibilityTimeout,
+			Start:    cursor,
+			Count:    50,
+		}).Result()
+		if err != nil {
+			return fmt.Errorf("xautoclaim failed: %w", err)
+		}
+
+		for _, msg := range claimed {
+			deliveries := deliveryCount(ctx, msg.ID)
+			if deliveries >= maxDeliveries {
+				if err := deadLetter(ctx, msg, "max deliveries exceeded", onDead); err != nil {
+					return err
+				}
+				continue
+			}
+			queueRetries.Inc()
+		}
+
+		if next == "0-0" || len(claimed) == 0 {
+			break
+		}
+		cursor = next
+	}
+
+	if length, err := Client.XLen(ctx, cfg().DLQStream).Result(); err == nil {
+		queueDLQSize.Set(float64(length))
+	}
+	return nil
+}
+
+func deadLetter(ctx context.Context, msg redis.XMessage, reason string, onDead func(ctx context.Context, d *Delivery, lastErr string) error) error {
+	delivery, err := deliveryFromMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	if err := Client.XAdd(ctx, &redis.XAddArgs{
+		Stream: cfg().DLQStream,
+		Values: map[string]interface{}{"task": mustJSON(delivery.Task), "reason": reason},
+	}).Err(); err != nil {
+		return fmt.Errorf("failed to publish to dlq: %w", err)
+	}
+
+	if onDead != nil {
+		if err := onDead(ctx, delivery, reason); err != nil {
+			return err
+		}
+	}
+
+	pipe := Client.TxPipeline()
+	pipe.XAck(ctx, cfg().Stream, cfg().ConsumerGroup, msg.ID)
+	pipe.XDel(ctx, cfg().Stream, msg.ID)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// deliveryCount reads the number of times a message has been delivered from XPENDING.
+func deliveryCount(ctx context.Context, streamID string) int64 {
+	pending, err := Client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: cfg().Stream,
+		Group:  cfg().ConsumerGroup,
+		Start:  streamID,
+		End:    streamID,
+		Count:  1,
+	}).Result()
+	if err != nil || len(pending) == 0 {
+		return 0
+	}
+	return pending[0].RetryCount
+}
+
+func deliveryFromMessage(msg redis.XMessage) (*Delivery, error) {
+	raw, _ := msg.Values["task"].(string)
+	var task Task
+	if err := json.Unmarshal([]byte(raw), &task); err != nil {
+		return nil, fmt.Errorf("failed to decode task payload: %w", err)
+	}
+	return &Delivery{StreamID: msg.ID, Task: task}, nil
+}
+
+func mustJSON(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
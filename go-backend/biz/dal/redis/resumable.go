@@ -0,0 +1,139 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// resumableSessionTTL is how long an upload session survives after it is created
+// before Redis expires it and the client has to start over. PATCH updates keep the
+// remaining TTL; they do not extend it.
+const resumableSessionTTL = 24 * time.Hour
+
+// ResumablePart is one byte range already written into the MinIO multipart upload.
+type ResumablePart struct {
+	PartNumber int    `json:"part_number"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
+}
+
+// Resumable session statuses.
+const (
+	ResumableStatusPending   = "PENDING"
+	ResumableStatusCompleted = "COMPLETED"
+)
+
+// ResumableSession is a tus-style resumable upload session, persisted in Redis
+// (not Postgres, unlike the simpler multipart flow in model.AssetUpload) since
+// it's short-lived, single-writer state that's fine to lose if Redis is flushed.
+type ResumableSession struct {
+	ID            string          `json:"id"`
+	Bucket        string          `json:"bucket"`
+	ObjectName    string          `json:"object_name"`
+	MimeType      string          `json:"mime_type"`
+	Owner         string          `json:"owner,omitempty"`
+	MinIOUploadID string          `json:"minio_upload_id"`
+	TotalSize     int64           `json:"total_size"`
+	Received      int64           `json:"received"`
+	NextPart      int             `json:"next_part"`
+	Parts         []ResumablePart `json:"parts"`
+	Status        string          `json:"status"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func resumableKey(id string) string {
+	return "thinkbank:resumable:" + id
+}
+
+// CreateResumableSession persists a new upload session with the standard TTL.
+func CreateResumableSession(ctx context.Context, session *ResumableSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resumable session: %w", err)
+	}
+	if err := Client.Set(ctx, resumableKey(session.ID), data, resumableSessionTTL).Err(); err != nil {
+		return fmt.Errorf("failed to save resumable session: %w", err)
+	}
+	return nil
+}
+
+// GetResumableSession loads a session by ID. Returns redis.Nil (via errors.Is) if the
+// session doesn't exist or has expired.
+func GetResumableSession(ctx context.Context, id string) (*ResumableSession, error) {
+	data, err := Client.Get(ctx, resumableKey(id)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var session ResumableSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resumable session: %w", err)
+	}
+	return &session, nil
+}
+
+// DeleteResumableSession removes a session, e.g. once it's been completed.
+func DeleteResumableSession(ctx context.Context, id string) error {
+	return Client.Del(ctx, resumableKey(id)).Err()
+}
+
+// ErrResumableSessionChanged is returned by UpdateResumableSession's mutate callback
+// to signal that the session no longer matches what the caller expected (e.g. a
+// concurrent PATCH already advanced it), so the caller should reject the request
+// instead of applying a stale mutation.
+var ErrResumableSessionChanged = errors.New("resumable session changed concurrently")
+
+// UpdateResumableSession loads the session, applies mutate to it, and saves the
+// result, all inside a WATCH/MULTI transaction keyed on the session so a concurrent
+// PATCH for the same upload can't silently clobber this one's Parts/Received — the
+// same read-modify-write race chunk0-1 closed for the Postgres-backed AssetUpload via
+// row locking; Redis has no row lock, so optimistic CAS via WATCH is the primitive.
+// Retries automatically if another writer updates the session first.
+func UpdateResumableSession(ctx context.Context, id string, mutate func(*ResumableSession) error) (*ResumableSession, error) {
+	key := resumableKey(id)
+	for {
+		var result *ResumableSession
+		err := Client.Watch(ctx, func(tx *redis.Tx) error {
+			data, err := tx.Get(ctx, key).Bytes()
+			if err != nil {
+				return err
+			}
+			var session ResumableSession
+			if err := json.Unmarshal(data, &session); err != nil {
+				return fmt.Errorf("failed to unmarshal resumable session: %w", err)
+			}
+
+			if err := mutate(&session); err != nil {
+				return err
+			}
+
+			updated, err := json.Marshal(session)
+			if err != nil {
+				return fmt.Errorf("failed to marshal resumable session: %w", err)
+			}
+
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, key, updated, redis.KeepTTL)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			result = &session
+			return nil
+		}, key)
+
+		if err == redis.TxFailedErr {
+			continue // key changed since Get; retry with fresh data
+		}
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+}
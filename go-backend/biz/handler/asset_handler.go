@@ -3,7 +3,12 @@ package handler
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -12,12 +17,14 @@ import (
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"thinkbank/backend/biz/dal/minio"
 	"thinkbank/backend/biz/dal/postgres"
 	"thinkbank/backend/biz/dal/redis"
 	"thinkbank/backend/biz/model"
 	"thinkbank/backend/biz/pkg/errno"
+	"thinkbank/backend/biz/pkg/thumbnail"
 )
 
 // UploadRequest represents file upload request
@@ -27,8 +34,9 @@ type UploadRequest struct {
 
 // UploadResponse represents upload response
 type UploadResponse struct {
-	AssetID string `json:"asset_id"`
-	Message string `json:"message"`
+	AssetID   string `json:"asset_id"`
+	Message   string `json:"message"`
+	Duplicate bool   `json:"duplicate,omitempty"`
 }
 
 // AssetResponse represents a single asset
@@ -40,6 +48,8 @@ type AssetResponse struct {
 	Caption          string                 `json:"caption,omitempty"`
 	ProcessingStatus string                 `json:"processing_status"`
 	URL              string                 `json:"url,omitempty"`
+	BlurHash         string                 `json:"blur_hash,omitempty"`
+	ThumbnailURL     string                 `json:"thumbnail_url,omitempty"`
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
 	CreatedAt        time.Time              `json:"created_at"`
 }
@@ -74,16 +84,49 @@ func Upload(ctx context.Context, c *app.RequestContext) {
 		return
 	}
 
+	// Hash the upload (tee to a temp file) so we can dedup by content before
+	// ever touching MinIO or the processing queue.
+	contentHash, tmpPath, err := hashToTempFile(file)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.FileUploadErr)
+		return
+	}
+	defer os.Remove(tmpPath)
+
+	var existing model.Asset
+	err = postgres.DB.Where("content_hash = ?", contentHash).First(&existing).Error
+	if err == nil {
+		c.JSON(consts.StatusOK, UploadResponse{
+			AssetID:   existing.ID.String(),
+			Message:   "File already exists",
+			Duplicate: true,
+		})
+		return
+	}
+	if err != gorm.ErrRecordNotFound {
+		c.JSON(consts.StatusInternalServerError, errno.DBErr)
+		return
+	}
+
 	// Generate asset ID
 	assetID := uuid.New()
 
 	// Determine file extension
 	objectName := fmt.Sprintf("%s/%s", assetID.String(), assetID.String())
+	if ext := filepath.Ext(file.Filename); ext != "" {
+		objectName += ext
+	}
+
+	tmpFile, err := os.Open(tmpPath)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.FileUploadErr)
+		return
+	}
+	defer tmpFile.Close()
 
 	// Upload to MinIO
 	bucket := minio.GetBucket()
-	objectPath, err := minio.UploadFile(ctx, bucket, objectName, file)
-	if err != nil {
+	if err := minio.UploadFromReader(ctx, bucket, objectName, tmpFile, file.Size, contentType); err != nil {
 		c.JSON(consts.StatusInternalServerError, errno.MinIOErr)
 		return
 	}
@@ -92,12 +135,26 @@ func Upload(ctx context.Context, c *app.RequestContext) {
 	asset := &model.Asset{
 		ID:               assetID,
 		BucketName:       bucket,
-		ObjectName:       objectPath,
+		ObjectName:       objectName,
 		MimeType:         contentType,
 		SizeBytes:        file.Size,
+		ContentHash:      contentHash,
+		Owner:            string(c.GetHeader("X-User-ID")),
 		ProcessingStatus: model.StatusPending,
 	}
 
+	// Images get a BlurHash placeholder and WebP thumbnail up front, synchronously,
+	// generated straight from tmpFile instead of re-downloading what was just
+	// uploaded. Best-effort: a generation failure shouldn't fail the upload.
+	if strings.HasPrefix(contentType, "image/") {
+		if _, err := tmpFile.Seek(0, io.SeekStart); err == nil {
+			if blurHash, thumbnailObject, err := thumbnail.Process(ctx, tmpFile, bucket, assetID.String()); err == nil {
+				asset.BlurHash = blurHash
+				asset.ThumbnailObject = thumbnailObject
+			}
+		}
+	}
+
 	if err := postgres.DB.Create(asset).Error; err != nil {
 		c.JSON(consts.StatusInternalServerError, errno.DBErr)
 		return
@@ -165,6 +222,8 @@ func ListAssets(ctx context.Context, c *app.RequestContext) {
 			SizeBytes:        asset.SizeBytes,
 			Caption:          asset.Caption,
 			ProcessingStatus: asset.ProcessingStatus,
+			BlurHash:         asset.BlurHash,
+			ThumbnailURL:     resolveThumbnailURL(ctx, asset),
 			Metadata:         asset.Metadata,
 			CreatedAt:        asset.CreatedAt,
 		}
@@ -202,6 +261,8 @@ func GetAsset(ctx context.Context, c *app.RequestContext) {
 		Caption:          asset.Caption,
 		ProcessingStatus: asset.ProcessingStatus,
 		URL:              url,
+		BlurHash:         asset.BlurHash,
+		ThumbnailURL:     resolveThumbnailURL(ctx, asset),
 		Metadata:         asset.Metadata,
 		CreatedAt:        asset.CreatedAt,
 	})
@@ -234,6 +295,45 @@ func DeleteAsset(ctx context.Context, c *app.RequestContext) {
 
 // Helper functions
 
+// hashToTempFile copies an uploaded file to a temp file while computing its
+// SHA-256, so the content hash is known before anything is uploaded to MinIO.
+// The caller is responsible for removing the returned temp file.
+func hashToTempFile(file *multipart.FileHeader) (contentHash, tmpPath string, err error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to open upload: %w", err)
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp("", "thinkbank-upload-*")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer tmp.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hasher), src); err != nil {
+		os.Remove(tmp.Name())
+		return "", "", fmt.Errorf("failed to hash upload: %w", err)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), tmp.Name(), nil
+}
+
+// resolveThumbnailURL presigns the asset's thumbnail, if Upload generated one;
+// assets without ThumbnailObject (non-image, uploaded through the multipart or
+// resumable flows, or whose thumbnail generation failed) get no thumbnail_url.
+func resolveThumbnailURL(ctx context.Context, asset model.Asset) string {
+	if asset.ThumbnailObject == "" {
+		return ""
+	}
+	url, err := minio.GetFileURL(ctx, asset.BucketName, asset.ThumbnailObject, 3600)
+	if err != nil {
+		return ""
+	}
+	return url
+}
+
 func isValidFileType(contentType string) bool {
 	validTypes := []string{
 		"image/jpeg",
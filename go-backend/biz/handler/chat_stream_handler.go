@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+const sseHeartbeatInterval = 15 * time.Second
+
+type llmStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// ChatStream performs RAG and streams the answer to the client over SSE as it's
+// generated, instead of blocking on the full completion (GET/POST /chat/stream).
+func ChatStream(ctx context.Context, c *app.RequestContext) {
+	var req ChatRequest
+	if err := c.BindAndValidate(&req); err != nil {
+		c.JSON(consts.StatusBadRequest, map[string]string{"message": "invalid request"})
+		return
+	}
+
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" {
+		c.JSON(consts.StatusBadRequest, map[string]string{"message": "query is required"})
+		return
+	}
+
+	topK := req.TopK
+	if topK <= 0 {
+		topK = 5
+	}
+	if topK > 12 {
+		topK = 12
+	}
+
+	sources, err := retrieveAssetsForQuery(ctx, req.Query, topK, 0, defaultFusionParams())
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, map[string]string{"message": err.Error()})
+		return
+	}
+
+	c.Response.Header.Set("Content-Type", "text/event-stream")
+	c.Response.Header.Set("Cache-Control", "no-cache")
+	c.Response.Header.Set("Connection", "keep-alive")
+	c.SetStatusCode(consts.StatusOK)
+
+	// writeMu serializes every write to c's body writer: streamLLMForRAG writes
+	// tokens from its own goroutine while this goroutine's heartbeat case writes
+	// to the same connection, and unsynchronized concurrent writes/flushes would
+	// interleave SSE frames.
+	var writeMu sync.Mutex
+
+	writeSSEEvent(c, &writeMu, "sources", sources)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		streamLLMForRAG(ctx, c, &writeMu, req.Query, req.History, sources)
+	}()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-done:
+			writeSSEEvent(c, &writeMu, "done", map[string]string{})
+			return
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			writeMu.Lock()
+			c.Response.BodyWriter().Write([]byte(": heartbeat\n\n"))
+			c.Flush()
+			writeMu.Unlock()
+		}
+	}
+}
+
+// streamLLMForRAG calls the LLM with Stream:true and forwards each OpenAI-compatible
+// `data: {...}` delta to the client as an `event: token` SSE message.
+func streamLLMForRAG(ctx context.Context, c *app.RequestContext, writeMu *sync.Mutex, query string, history []ChatMessage, sources []SearchResult) {
+	baseURL := strings.TrimRight(getEnv("LLM_API_URL", "http://127.0.0.1:8000/v1"), "/")
+	apiKey := getEnv("LLM_API_KEY", "sk-local")
+	modelName := getEnv("LLM_MODEL", "Qwen/Qwen3-VL-8B-Instruct-GPTQ-Int4")
+
+	var contextBuilder strings.Builder
+	for idx, src := range sources {
+		contextBuilder.WriteString("[" + fmt.Sprint(idx+1) + "] ")
+		contextBuilder.WriteString("id=" + src.ID + ", file=" + src.FileName + ", mime=" + src.MimeType + "\n")
+		if strings.TrimSpace(src.Caption) != "" {
+			contextBuilder.WriteString("caption: " + src.Caption + "\n")
+		}
+		if strings.TrimSpace(src.ContentPreview) != "" {
+			contextBuilder.WriteString("text: " + src.ContentPreview + "\n")
+		}
+		contextBuilder.WriteString("\n")
+	}
+
+	systemPrompt := "You are ThinkBank assistant. Answer based on provided context. If uncertain, say what is missing."
+	userPrompt := "User query:\n" + query + "\n\nRetrieved context:\n" + contextBuilder.String()
+
+	messages := []llmChatMsg{{Role: "system", Content: systemPrompt}}
+	for _, h := range history {
+		role := strings.ToLower(strings.TrimSpace(h.Role))
+		if role != "assistant" && role != "system" {
+			role = "user"
+		}
+		content := strings.TrimSpace(h.Content)
+		if content == "" {
+			continue
+		}
+		messages = append(messages, llmChatMsg{Role: role, Content: content})
+	}
+	messages = append(messages, llmChatMsg{Role: "user", Content: userPrompt})
+
+	payload := llmChatRequest{
+		Model:       modelName,
+		Messages:    messages,
+		Temperature: 0.2,
+		MaxTokens:   800,
+		TopP:        0.9,
+		Stream:      true,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		writeSSEEvent(c, writeMu, "error", map[string]string{"message": err.Error()})
+		return
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		writeSSEEvent(c, writeMu, "error", map[string]string{"message": err.Error()})
+		return
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	client := &http.Client{Timeout: 0}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		writeSSEEvent(c, writeMu, "error", map[string]string{"message": err.Error()})
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		writeSSEEvent(c, writeMu, "error", map[string]string{"message": fmt.Sprintf("llm request failed: status=%d", resp.StatusCode)})
+		return
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return
+		}
+
+		var chunk llmStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		writeSSEEvent(c, writeMu, "token", map[string]string{"content": chunk.Choices[0].Delta.Content})
+	}
+}
+
+// writeSSEEvent writes and flushes a single "event: <name>\ndata: <json>\n\n" frame.
+func writeSSEEvent(c *app.RequestContext, writeMu *sync.Mutex, event string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	frame := fmt.Sprintf("event: %s\ndata: %s\n\n", event, data)
+
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	c.Response.BodyWriter().Write([]byte(frame))
+	c.Flush()
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	"thinkbank/backend/biz/dal/postgres"
+	"thinkbank/backend/biz/model"
+	"thinkbank/backend/biz/pkg/errno"
+	"thinkbank/backend/biz/pkg/search"
+)
+
+// HybridSearchRequest is the body for POST /assets/search.
+type HybridSearchRequest struct {
+	Query      string              `json:"query"`
+	Modalities []string            `json:"modalities"`
+	TopK       int                 `json:"top_k"`
+	Filters    HybridSearchFilters `json:"filters"`
+}
+
+// HybridSearchFilters narrows the candidate set before retrieval.
+type HybridSearchFilters struct {
+	MimeTypePrefix   string `json:"mime_type_prefix"`
+	ProcessingStatus string `json:"processing_status"`
+}
+
+// HybridSearchResult is one fused, asset-hydrated search hit.
+type HybridSearchResult struct {
+	AssetResponse
+	Score       float64            `json:"score"`
+	DebugScores map[string]float64 `json:"debug_scores,omitempty"`
+}
+
+// HybridSearchResponse is the response for POST /assets/search.
+type HybridSearchResponse struct {
+	Query   string               `json:"query"`
+	Total   int                  `json:"total"`
+	Results []HybridSearchResult `json:"results"`
+}
+
+// HybridSearch runs the multi-retriever RRF pipeline in pkg/search (POST /assets/search).
+func HybridSearch(ctx context.Context, c *app.RequestContext) {
+	var req HybridSearchRequest
+	if err := c.BindAndValidate(&req); err != nil {
+		c.JSON(consts.StatusBadRequest, errno.ParamErr)
+		return
+	}
+
+	modalities := make([]search.Modality, 0, len(req.Modalities))
+	for _, m := range req.Modalities {
+		modalities = append(modalities, search.Modality(m))
+	}
+
+	hits, err := search.Search(ctx, req.Query, modalities, req.TopK, search.Filters{
+		MimeTypePrefix:   req.Filters.MimeTypePrefix,
+		ProcessingStatus: req.Filters.ProcessingStatus,
+	}, embedQueryTextForSearch, embedQueryVisualForSearch)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.InternalErr)
+		return
+	}
+
+	results := hydrateHits(ctx, hits)
+
+	c.JSON(consts.StatusOK, HybridSearchResponse{
+		Query:   req.Query,
+		Total:   len(results),
+		Results: results,
+	})
+}
+
+// embedQueryTextForSearch adapts embedQueryText (search_handler.go) to pkg/search's EmbedFunc shape.
+func embedQueryTextForSearch(ctx context.Context, query string) []float64 {
+	return embedQueryText(query)
+}
+
+// embedQueryVisualForSearch adapts embedQueryImage (search_handler.go) to pkg/search's
+// EmbedFunc shape, so the image retriever queries visual_vector with a CLIP embedding
+// instead of the BGE-M3 text embedding used for semantic_vector.
+func embedQueryVisualForSearch(ctx context.Context, query string) []float64 {
+	return embedQueryImage(query)
+}
+
+func hydrateHits(ctx context.Context, hits []search.Hit) []HybridSearchResult {
+	if len(hits) == 0 {
+		return []HybridSearchResult{}
+	}
+
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = h.AssetID
+	}
+
+	var assets []model.Asset
+	if err := postgres.DB.WithContext(ctx).Where("id IN ?", ids).Find(&assets).Error; err != nil {
+		return []HybridSearchResult{}
+	}
+
+	byID := make(map[string]model.Asset, len(assets))
+	for _, a := range assets {
+		byID[a.ID.String()] = a
+	}
+
+	results := make([]HybridSearchResult, 0, len(hits))
+	for _, h := range hits {
+		asset, ok := byID[h.AssetID]
+		if !ok {
+			continue
+		}
+		results = append(results, HybridSearchResult{
+			AssetResponse: AssetResponse{
+				ID:               asset.ID.String(),
+				FileName:         filepath.Base(asset.ObjectName),
+				MimeType:         asset.MimeType,
+				SizeBytes:        asset.SizeBytes,
+				Caption:          asset.Caption,
+				ProcessingStatus: asset.ProcessingStatus,
+				BlurHash:         asset.BlurHash,
+				ThumbnailURL:     resolveThumbnailURL(ctx, asset),
+				Metadata:         asset.Metadata,
+				CreatedAt:        asset.CreatedAt,
+			},
+			Score:       h.Score,
+			DebugScores: h.DebugScores,
+		})
+	}
+	return results
+}
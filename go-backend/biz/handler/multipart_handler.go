@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/google/uuid"
+	miniogo "github.com/minio/minio-go/v7"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"thinkbank/backend/biz/dal/minio"
+	"thinkbank/backend/biz/dal/postgres"
+	"thinkbank/backend/biz/dal/redis"
+	"thinkbank/backend/biz/model"
+	"thinkbank/backend/biz/pkg/errno"
+)
+
+const multipartUploadTTL = 24 * time.Hour
+
+// InitiateMultipartRequest starts a resumable multipart upload.
+type InitiateMultipartRequest struct {
+	FileName  string `json:"file_name"`
+	MimeType  string `json:"mime_type"`
+	TotalSize int64  `json:"total_size"`
+}
+
+// InitiateMultipartResponse returns the upload session a client uploads parts against.
+type InitiateMultipartResponse struct {
+	UploadID  string    `json:"uploadId"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// UploadPartResponse confirms a single part was stored.
+type UploadPartResponse struct {
+	PartNumber int   `json:"part_number"`
+	Received   int64 `json:"received"`
+}
+
+// CompleteMultipartResponse reports the resulting asset once all parts are stitched together.
+type CompleteMultipartResponse struct {
+	AssetID string `json:"asset_id"`
+	Message string `json:"message"`
+}
+
+// InitiateUpload creates a multipart upload session (POST /assets/uploads).
+func InitiateUpload(ctx context.Context, c *app.RequestContext) {
+	var req InitiateMultipartRequest
+	if err := c.BindAndValidate(&req); err != nil || req.FileName == "" {
+		c.JSON(consts.StatusBadRequest, errno.ParamErr)
+		return
+	}
+
+	bucket := minio.GetBucket()
+	objectName := fmt.Sprintf("%s/%s", uuid.New().String(), req.FileName)
+
+	minioUploadID, err := minio.InitiateMultipart(ctx, bucket, objectName, req.MimeType)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.MinIOErr)
+		return
+	}
+
+	upload := &model.AssetUpload{
+		UploadID:   minioUploadID,
+		BucketName: bucket,
+		ObjectName: objectName,
+		MimeType:   req.MimeType,
+		Owner:      string(c.GetHeader("X-User-ID")),
+		TotalSize:  req.TotalSize,
+		Status:     model.UploadStatusPending,
+		ExpiresAt:  time.Now().Add(multipartUploadTTL),
+	}
+	if err := postgres.DB.Create(upload).Error; err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.DBErr)
+		return
+	}
+
+	c.JSON(consts.StatusOK, InitiateMultipartResponse{
+		UploadID:  upload.ID.String(),
+		ExpiresAt: upload.ExpiresAt,
+	})
+}
+
+// UploadPart stores a single part of an in-progress upload (PUT /assets/uploads/:id/parts/:n).
+func UploadPart(ctx context.Context, c *app.RequestContext) {
+	upload, ok := findPendingUpload(c)
+	if !ok {
+		return
+	}
+
+	partNumber, err := strconv.Atoi(c.Param("n"))
+	if err != nil || partNumber <= 0 {
+		c.JSON(consts.StatusBadRequest, errno.ParamErr)
+		return
+	}
+
+	body := c.Request.BodyStream()
+	size := int64(c.Request.Header.ContentLength())
+	if body == nil || size <= 0 {
+		c.JSON(consts.StatusBadRequest, errno.FileUploadErr)
+		return
+	}
+
+	etag, err := minio.UploadPart(ctx, upload.BucketName, upload.ObjectName, upload.UploadID, partNumber, body, size)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.MinIOErr)
+		return
+	}
+
+	// Row-lock the upload for the read-modify-write of Parts/Received: two parts
+	// uploaded concurrently both load the same upload row, and without a lock the
+	// second writer's Updates() would silently overwrite the first writer's part.
+	var received int64
+	err = postgres.DB.Transaction(func(tx *gorm.DB) error {
+		var locked model.AssetUpload
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", upload.ID).First(&locked).Error; err != nil {
+			return err
+		}
+
+		parts := append(removePart(locked.Parts, partNumber), model.UploadedPart{
+			PartNumber: partNumber,
+			ETag:       etag,
+			Size:       size,
+		})
+		sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+
+		received = 0
+		for _, p := range parts {
+			received += p.Size
+		}
+
+		return tx.Model(&locked).Updates(map[string]interface{}{
+			"parts":    model.UploadedParts(parts),
+			"received": received,
+		}).Error
+	})
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.DBErr)
+		return
+	}
+
+	c.JSON(consts.StatusOK, UploadPartResponse{PartNumber: partNumber, Received: received})
+}
+
+// CompleteUpload stitches the uploaded parts into the final object and creates the Asset (POST /assets/uploads/:id/complete).
+func CompleteUpload(ctx context.Context, c *app.RequestContext) {
+	upload, ok := findPendingUpload(c)
+	if !ok {
+		return
+	}
+	if len(upload.Parts) == 0 {
+		c.JSON(consts.StatusBadRequest, errno.ParamErr)
+		return
+	}
+
+	completeParts := make([]miniogo.CompletePart, len(upload.Parts))
+	for i, p := range upload.Parts {
+		completeParts[i] = miniogo.CompletePart{PartNumber: p.PartNumber, ETag: p.ETag}
+	}
+
+	if err := minio.CompleteMultipart(ctx, upload.BucketName, upload.ObjectName, upload.UploadID, completeParts); err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.MinIOErr)
+		return
+	}
+
+	asset := &model.Asset{
+		BucketName:       upload.BucketName,
+		ObjectName:       upload.ObjectName,
+		MimeType:         upload.MimeType,
+		SizeBytes:        upload.Received,
+		Owner:            upload.Owner,
+		ProcessingStatus: model.StatusPending,
+	}
+
+	err := postgres.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(asset).Error; err != nil {
+			return err
+		}
+		task := &model.ProcessingTask{
+			AssetID: asset.ID,
+			Status:  model.StatusPending,
+			Stage:   model.StageQueued,
+		}
+		if err := tx.Create(task).Error; err != nil {
+			return err
+		}
+		return tx.Model(upload).Updates(map[string]interface{}{
+			"status":   model.UploadStatusCompleted,
+			"asset_id": asset.ID,
+		}).Error
+	})
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.DBErr)
+		return
+	}
+
+	if err := redis.PushTask(ctx, asset.ID.String()); err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.RedisErr)
+		return
+	}
+
+	c.JSON(consts.StatusOK, CompleteMultipartResponse{
+		AssetID: asset.ID.String(),
+		Message: "Upload completed successfully",
+	})
+}
+
+// AbortUpload cancels an in-progress multipart upload (DELETE /assets/uploads/:id).
+func AbortUpload(ctx context.Context, c *app.RequestContext) {
+	upload, ok := findPendingUpload(c)
+	if !ok {
+		return
+	}
+
+	if err := minio.AbortMultipart(ctx, upload.BucketName, upload.ObjectName, upload.UploadID); err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.MinIOErr)
+		return
+	}
+
+	if err := postgres.DB.Model(upload).Update("status", model.UploadStatusAborted).Error; err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.DBErr)
+		return
+	}
+
+	c.JSON(consts.StatusOK, map[string]string{"message": "Upload aborted"})
+}
+
+func findPendingUpload(c *app.RequestContext) (*model.AssetUpload, bool) {
+	id := c.Param("id")
+
+	var upload model.AssetUpload
+	if err := postgres.DB.Where("id = ?", id).First(&upload).Error; err != nil {
+		c.JSON(consts.StatusNotFound, errno.FileNotFound)
+		return nil, false
+	}
+	if upload.Status != model.UploadStatusPending {
+		c.JSON(consts.StatusBadRequest, errno.ParamErr)
+		return nil, false
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		c.JSON(consts.StatusBadRequest, errno.ParamErr)
+		return nil, false
+	}
+	return &upload, true
+}
+
+func removePart(parts model.UploadedParts, partNumber int) model.UploadedParts {
+	out := make(model.UploadedParts, 0, len(parts))
+	for _, p := range parts {
+		if p.PartNumber == partNumber {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/google/uuid"
+	miniogo "github.com/minio/minio-go/v7"
+
+	"thinkbank/backend/biz/dal/minio"
+	"thinkbank/backend/biz/dal/postgres"
+	"thinkbank/backend/biz/dal/redis"
+	"thinkbank/backend/biz/model"
+	"thinkbank/backend/biz/pkg/errno"
+)
+
+// defaultMaxUploadBytes is the cap used when MAX_UPLOAD_BYTES isn't set.
+const defaultMaxUploadBytes = 5 * 1024 * 1024 * 1024 // 5GB
+
+// CreateResumableUploadRequest describes the file a client is about to upload in chunks.
+type CreateResumableUploadRequest struct {
+	FileName  string `json:"file_name"`
+	MimeType  string `json:"mime_type"`
+	TotalSize int64  `json:"total_size"`
+}
+
+// CreateResumableUploadResponse returns where the client should PATCH chunks.
+type CreateResumableUploadResponse struct {
+	UploadID  string `json:"upload_id"`
+	UploadURL string `json:"upload_url"`
+}
+
+// ResumableUploadChunkResponse reports progress after a chunk, and the resulting
+// asset once the upload completes.
+type ResumableUploadChunkResponse struct {
+	Received int64  `json:"received"`
+	Total    int64  `json:"total"`
+	AssetID  string `json:"asset_id,omitempty"`
+}
+
+// ResumableUploadProgressResponse drives client-side progress bars.
+type ResumableUploadProgressResponse struct {
+	Received int64   `json:"received"`
+	Total    int64   `json:"total"`
+	Percent  float64 `json:"percent"`
+}
+
+// CreateResumableUpload opens a tus-style resumable upload session (POST /uploads).
+// Session state lives in Redis with a TTL, unlike the Postgres-backed multipart flow
+// in multipart_handler.go — it's short-lived, single-writer state that's fine to lose
+// if the session expires before the client resumes.
+func CreateResumableUpload(ctx context.Context, c *app.RequestContext) {
+	var req CreateResumableUploadRequest
+	if err := c.BindAndValidate(&req); err != nil || req.FileName == "" {
+		c.JSON(consts.StatusBadRequest, errno.ParamErr)
+		return
+	}
+	if req.TotalSize <= 0 || req.TotalSize > maxUploadBytes() {
+		c.JSON(consts.StatusBadRequest, errno.FileTooLarge)
+		return
+	}
+
+	bucket := minio.GetBucket()
+	objectName := fmt.Sprintf("%s/%s", uuid.New().String(), req.FileName)
+
+	minioUploadID, err := minio.InitiateMultipart(ctx, bucket, objectName, req.MimeType)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.MinIOErr)
+		return
+	}
+
+	session := &redis.ResumableSession{
+		ID:            uuid.New().String(),
+		Bucket:        bucket,
+		ObjectName:    objectName,
+		MimeType:      req.MimeType,
+		Owner:         string(c.GetHeader("X-User-ID")),
+		MinIOUploadID: minioUploadID,
+		TotalSize:     req.TotalSize,
+		NextPart:      1,
+		Status:        redis.ResumableStatusPending,
+		CreatedAt:     time.Now(),
+	}
+	if err := redis.CreateResumableSession(ctx, session); err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.RedisErr)
+		return
+	}
+
+	c.JSON(consts.StatusOK, CreateResumableUploadResponse{
+		UploadID:  session.ID,
+		UploadURL: "/uploads/" + session.ID,
+	})
+}
+
+// PatchResumableUpload streams one byte range into the MinIO multipart upload
+// (PATCH /uploads/:id). Upload-Offset must match the session's current Received
+// so chunks are applied in order; Content-Length gives the chunk size.
+func PatchResumableUpload(ctx context.Context, c *app.RequestContext) {
+	session, ok := loadPendingResumableSession(ctx, c)
+	if !ok {
+		return
+	}
+
+	offset, err := strconv.ParseInt(string(c.GetHeader("Upload-Offset")), 10, 64)
+	if err != nil || offset != session.Received {
+		c.JSON(consts.StatusConflict, map[string]string{"message": "upload offset does not match session"})
+		return
+	}
+
+	size := int64(c.Request.Header.ContentLength())
+	body := c.Request.BodyStream()
+	if body == nil || size <= 0 {
+		c.JSON(consts.StatusBadRequest, errno.FileUploadErr)
+		return
+	}
+	if session.Received+size > session.TotalSize {
+		c.JSON(consts.StatusBadRequest, errno.FileTooLarge)
+		return
+	}
+
+	partNumber := session.NextPart
+	etag, err := minio.UploadPart(ctx, session.Bucket, session.ObjectName, session.MinIOUploadID, partNumber, body, size)
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.MinIOErr)
+		return
+	}
+
+	// Apply the part and advance Received/NextPart under a CAS transaction: if a
+	// concurrent PATCH for this session already advanced it since we loaded it
+	// above, fail the request instead of silently clobbering the other writer.
+	part := redis.ResumablePart{PartNumber: partNumber, ETag: etag, Size: size}
+	updated, err := redis.UpdateResumableSession(ctx, session.ID, func(fresh *redis.ResumableSession) error {
+		if fresh.Received != offset || fresh.NextPart != partNumber {
+			return redis.ErrResumableSessionChanged
+		}
+		fresh.Parts = append(fresh.Parts, part)
+		fresh.Received += size
+		fresh.NextPart++
+		return nil
+	})
+	if err == redis.ErrResumableSessionChanged {
+		c.JSON(consts.StatusConflict, map[string]string{"message": "upload offset does not match session"})
+		return
+	}
+	if err != nil {
+		c.JSON(consts.StatusInternalServerError, errno.RedisErr)
+		return
+	}
+
+	resp := ResumableUploadChunkResponse{Received: updated.Received, Total: updated.TotalSize}
+
+	if updated.Received >= updated.TotalSize {
+		assetID, err := completeResumableUpload(ctx, updated)
+		if err != nil {
+			c.JSON(consts.StatusInternalServerError, errno.DBErr)
+			return
+		}
+		resp.AssetID = assetID
+	}
+
+	c.JSON(consts.StatusOK, resp)
+}
+
+// HeadResumableUpload reports the session's current offset (HEAD /uploads/:id).
+func HeadResumableUpload(ctx context.Context, c *app.RequestContext) {
+	session, err := redis.GetResumableSession(ctx, c.Param("id"))
+	if err != nil {
+		c.Status(consts.StatusNotFound)
+		return
+	}
+	c.Response.Header.Set("Upload-Offset", strconv.FormatInt(session.Received, 10))
+	c.Status(consts.StatusOK)
+}
+
+// ResumableUploadProgress returns {received, total, percent} for UI progress bars
+// (GET /uploads/:id/progress).
+func ResumableUploadProgress(ctx context.Context, c *app.RequestContext) {
+	session, err := redis.GetResumableSession(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(consts.StatusNotFound, errno.FileNotFound)
+		return
+	}
+
+	var percent float64
+	if session.TotalSize > 0 {
+		percent = float64(session.Received) / float64(session.TotalSize) * 100
+	}
+
+	c.JSON(consts.StatusOK, ResumableUploadProgressResponse{
+		Received: session.Received,
+		Total:    session.TotalSize,
+		Percent:  percent,
+	})
+}
+
+// completeResumableUpload stitches the uploaded parts together and creates the
+// Asset/ProcessingTask rows, exactly as the synchronous Upload handler does.
+func completeResumableUpload(ctx context.Context, session *redis.ResumableSession) (string, error) {
+	completeParts := make([]miniogo.CompletePart, len(session.Parts))
+	for i, p := range session.Parts {
+		completeParts[i] = miniogo.CompletePart{PartNumber: p.PartNumber, ETag: p.ETag}
+	}
+	if err := minio.CompleteMultipart(ctx, session.Bucket, session.ObjectName, session.MinIOUploadID, completeParts); err != nil {
+		return "", fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+
+	asset := &model.Asset{
+		BucketName:       session.Bucket,
+		ObjectName:       session.ObjectName,
+		MimeType:         session.MimeType,
+		SizeBytes:        session.Received,
+		Owner:            session.Owner,
+		ProcessingStatus: model.StatusPending,
+	}
+	if err := postgres.DB.Create(asset).Error; err != nil {
+		return "", fmt.Errorf("failed to create asset: %w", err)
+	}
+
+	task := &model.ProcessingTask{
+		AssetID: asset.ID,
+		Status:  model.StatusPending,
+		Stage:   model.StageQueued,
+	}
+	if err := postgres.DB.Create(task).Error; err != nil {
+		return "", fmt.Errorf("failed to create processing task: %w", err)
+	}
+
+	if err := redis.PushTask(ctx, asset.ID.String()); err != nil {
+		return "", fmt.Errorf("failed to enqueue processing task: %w", err)
+	}
+
+	if err := redis.DeleteResumableSession(ctx, session.ID); err != nil {
+		return "", fmt.Errorf("failed to clean up upload session: %w", err)
+	}
+
+	return asset.ID.String(), nil
+}
+
+func loadPendingResumableSession(ctx context.Context, c *app.RequestContext) (*redis.ResumableSession, bool) {
+	session, err := redis.GetResumableSession(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(consts.StatusNotFound, errno.FileNotFound)
+		return nil, false
+	}
+	if session.Status != redis.ResumableStatusPending {
+		c.JSON(consts.StatusBadRequest, errno.ParamErr)
+		return nil, false
+	}
+	return session, true
+}
+
+func maxUploadBytes() int64 {
+	raw := os.Getenv("MAX_UPLOAD_BYTES")
+	if raw == "" {
+		return defaultMaxUploadBytes
+	}
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || v <= 0 {
+		return defaultMaxUploadBytes
+	}
+	return v
+}
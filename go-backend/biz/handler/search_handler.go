@@ -33,6 +33,9 @@ type SearchResult struct {
 	ProcessingStatus string    `json:"processing_status"`
 	Score            float64   `json:"score"`
 	URL              string    `json:"url,omitempty"`
+	BlurHash         string    `json:"blur_hash,omitempty"`
+	ThumbnailURL     string    `json:"thumbnail_url,omitempty"`
+	RerankScore      *float64  `json:"rerank_score,omitempty"`
 	CreatedAt        time.Time `json:"created_at"`
 }
 
@@ -51,6 +54,7 @@ type ChatRequest struct {
 	Query   string        `json:"query"`
 	History []ChatMessage `json:"history"`
 	TopK    int           `json:"top_k"`
+	Rerank  bool          `json:"rerank"`
 }
 
 type ChatResponse struct {
@@ -84,9 +88,17 @@ type llmChatResponse struct {
 func Search(ctx context.Context, c *app.RequestContext) {
 	query := strings.TrimSpace(c.Query("q"))
 	limit := parseIntWithDefault(c.Query("limit"), 20)
-	threshold := parseFloatWithDefault(c.Query("threshold"), 0.15)
+	fusion := parseFusionParams(c)
 
-	results, err := retrieveAssetsForQuery(ctx, query, limit, threshold)
+	defaultThreshold := 0.15
+	if fusion.Mode == fusionRRF {
+		// RRF scores are bounded by ~len(lists)/(k+1), far smaller than the old
+		// weighted-sum range, so the legacy default would filter out everything.
+		defaultThreshold = 0.0
+	}
+	threshold := parseFloatWithDefault(c.Query("threshold"), defaultThreshold)
+
+	results, err := retrieveAssetsForQuery(ctx, query, limit, threshold, fusion)
 	if err != nil {
 		c.JSON(consts.StatusInternalServerError, map[string]string{"message": err.Error()})
 		return
@@ -121,12 +133,25 @@ func Chat(ctx context.Context, c *app.RequestContext) {
 		topK = 12
 	}
 
-	sources, err := retrieveAssetsForQuery(ctx, req.Query, topK, 0.05)
+	rerankEnabled := req.Rerank && getEnvBool("RAG_RERANK_ENABLED", false)
+	fetchLimit := topK
+	if rerankEnabled && fetchLimit < rerankCandidatePoolSize {
+		fetchLimit = rerankCandidatePoolSize
+	}
+
+	sources, err := retrieveAssetsForQuery(ctx, req.Query, fetchLimit, 0, defaultFusionParams())
 	if err != nil {
 		c.JSON(consts.StatusInternalServerError, map[string]string{"message": err.Error()})
 		return
 	}
 
+	if rerankEnabled {
+		sources = rerankSources(ctx, req.Query, sources)
+	}
+	if len(sources) > topK {
+		sources = sources[:topK]
+	}
+
 	answer, err := callLLMForRAG(req.Query, req.History, sources)
 	if err != nil {
 		c.JSON(consts.StatusBadGateway, map[string]string{"message": err.Error()})
@@ -139,7 +164,74 @@ func Chat(ctx context.Context, c *app.RequestContext) {
 	})
 }
 
-func retrieveAssetsForQuery(ctx context.Context, query string, limit int, threshold float64) ([]SearchResult, error) {
+// fusionMode selects how the vector and character retrieval lists are combined.
+type fusionMode string
+
+const (
+	fusionRRF      fusionMode = "rrf"
+	fusionWeighted fusionMode = "weighted"
+)
+
+// fusionParams configures retrieveAssetsForQuery's hybrid scoring.
+type fusionParams struct {
+	Mode fusionMode
+	K    int // RRF smoothing constant
+}
+
+func defaultFusionParams() fusionParams {
+	return fusionParams{Mode: fusionRRF, K: 60}
+}
+
+// parseFusionParams reads `fusion=rrf|weighted` and `k=60` from the request so callers
+// can A/B the two hybrid scoring strategies.
+func parseFusionParams(c *app.RequestContext) fusionParams {
+	params := defaultFusionParams()
+	if mode := fusionMode(strings.ToLower(strings.TrimSpace(c.Query("fusion")))); mode == fusionWeighted {
+		params.Mode = fusionWeighted
+	}
+	if k := parseIntWithDefault(c.Query("k"), params.K); k > 0 {
+		params.K = k
+	}
+	return params
+}
+
+// rankDescending returns asset IDs ordered by descending score, skipping zero scores
+// (zero means "absent from this retriever's list" for RRF purposes).
+func rankDescending(scores map[string]float64) []string {
+	type pair struct {
+		id    string
+		score float64
+	}
+	pairs := make([]pair, 0, len(scores))
+	for id, score := range scores {
+		if score <= 0 {
+			continue
+		}
+		pairs = append(pairs, pair{id, score})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].score > pairs[j].score })
+
+	ranked := make([]string, len(pairs))
+	for i, p := range pairs {
+		ranked[i] = p.id
+	}
+	return ranked
+}
+
+// fuseRRF combines independently-ranked score maps via Reciprocal Rank Fusion:
+// score(a) = Σ_i 1/(k + rank_i(a)), where rank_i is the 1-based position of a in
+// retriever i's ranking and an absent asset contributes 0 for that retriever.
+func fuseRRF(k int, scoreLists ...map[string]float64) map[string]float64 {
+	fused := make(map[string]float64)
+	for _, scores := range scoreLists {
+		for rank, id := range rankDescending(scores) {
+			fused[id] += 1.0 / float64(k+rank+1)
+		}
+	}
+	return fused
+}
+
+func retrieveAssetsForQuery(ctx context.Context, query string, limit int, threshold float64, fusion fusionParams) ([]SearchResult, error) {
 	if limit <= 0 {
 		limit = 20
 	}
@@ -167,10 +259,12 @@ func retrieveAssetsForQuery(ctx context.Context, query string, limit int, thresh
 	}
 	hasVectorScores := len(vectorScores) > 0
 
-	// ---------- Character + Vector hybrid ----------
-	results := make([]SearchResult, 0, len(assets))
+	// ---------- Character score per asset ----------
+	charScores := make(map[string]float64, len(assets)) // asset_id → normalized 0–1
+	fileNames := make(map[string]string, len(assets))
 	for _, asset := range assets {
 		fileName := filepath.Base(asset.ObjectName)
+		fileNames[asset.ID.String()] = fileName
 		searchText := normalizeSearchText(strings.Join([]string{
 			fileName,
 			asset.MimeType,
@@ -199,22 +293,29 @@ func retrieveAssetsForQuery(ctx context.Context, query string, limit int, thresh
 				charScore += float64(matchCount) / float64(len(queryRunes))
 			}
 		}
-		charScoreNorm := math.Min(charScore/3.0, 1.0)
-
-		// Vector score (already 0–1 cosine similarity)
-		vecScore := vectorScores[asset.ID.String()]
-
-		// Hybrid score
-		var finalScore float64
-		if hasVectorScores {
-			finalScore = 0.7*vecScore + 0.3*charScoreNorm
-		} else {
-			finalScore = charScoreNorm
+		charScores[asset.ID.String()] = math.Min(charScore/3.0, 1.0)
+	}
+
+	// ---------- Fuse vector + character retrieval ----------
+	var fused map[string]float64
+	if fusion.Mode == fusionWeighted {
+		fused = make(map[string]float64, len(assets))
+		for id, charScoreNorm := range charScores {
+			if hasVectorScores {
+				fused[id] = 0.7*vectorScores[id] + 0.3*charScoreNorm
+			} else {
+				fused[id] = charScoreNorm
+			}
 		}
+	} else {
+		fused = fuseRRF(fusion.K, vectorScores, charScores)
+	}
 
-		// Recency tie-break
-		finalScore += 0.001 * float64(time.Since(asset.CreatedAt).Hours()) * -1.0
-		finalScore = math.Round(finalScore*1000) / 1000
+	// ---------- Build results ----------
+	results := make([]SearchResult, 0, len(assets))
+	for _, asset := range assets {
+		id := asset.ID.String()
+		finalScore := math.Round(fused[id]*1000) / 1000
 
 		if hasQuery && finalScore < threshold {
 			continue
@@ -226,8 +327,8 @@ func retrieveAssetsForQuery(ctx context.Context, query string, limit int, thresh
 		}
 
 		results = append(results, SearchResult{
-			ID:               asset.ID.String(),
-			FileName:         fileName,
+			ID:               id,
+			FileName:         fileNames[id],
 			MimeType:         asset.MimeType,
 			SizeBytes:        asset.SizeBytes,
 			Caption:          asset.Caption,
@@ -235,10 +336,13 @@ func retrieveAssetsForQuery(ctx context.Context, query string, limit int, thresh
 			ProcessingStatus: asset.ProcessingStatus,
 			Score:            finalScore,
 			URL:              url,
+			BlurHash:         asset.BlurHash,
+			ThumbnailURL:     resolveThumbnailURL(ctx, asset),
 			CreatedAt:        asset.CreatedAt,
 		})
 	}
 
+	// Recency is a pure tie-break now, not folded into the fused score.
 	sort.Slice(results, func(i, j int) bool {
 		if results[i].Score == results[j].Score {
 			return results[i].CreatedAt.After(results[j].CreatedAt)
@@ -252,6 +356,78 @@ func retrieveAssetsForQuery(ctx context.Context, query string, limit int, thresh
 	return results, nil
 }
 
+// rerankCandidatePoolSize is how many hybrid-retrieval candidates are sent to
+// the cross-encoder before the result is truncated to the caller's topK.
+const rerankCandidatePoolSize = 30
+
+type rerankRequest struct {
+	Query      string   `json:"query"`
+	Candidates []string `json:"candidates"`
+}
+
+type rerankResponse struct {
+	Scores []float64 `json:"scores"`
+}
+
+// rerankSources re-scores sources with a cross-encoder via the python-ai rerank
+// endpoint and re-sorts by that score, so semantically-close-but-off-topic
+// results don't outrank the right one. Falls back to the original ordering
+// (RerankScore left nil) if the service errors, times out, or disagrees on
+// the candidate count.
+func rerankSources(ctx context.Context, query string, sources []SearchResult) []SearchResult {
+	if len(sources) == 0 {
+		return sources
+	}
+
+	candidates := make([]string, len(sources))
+	for i, s := range sources {
+		candidates[i] = strings.TrimSpace(s.Caption + " " + s.ContentPreview)
+	}
+
+	payload, err := json.Marshal(rerankRequest{Query: query, Candidates: candidates})
+	if err != nil {
+		return sources
+	}
+
+	rerankURL := getEnv("AI_EMBED_URL", "http://127.0.0.1:50052") + "/api/rerank"
+	httpCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(httpCtx, http.MethodPost, rerankURL, bytes.NewReader(payload))
+	if err != nil {
+		return sources
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		fmt.Printf("rerank request failed, falling back to original ordering: %v\n", err)
+		return sources
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		fmt.Printf("rerank request failed: status=%d\n", resp.StatusCode)
+		return sources
+	}
+
+	var result rerankResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || len(result.Scores) != len(sources) {
+		fmt.Printf("rerank response malformed, falling back to original ordering\n")
+		return sources
+	}
+
+	for i := range sources {
+		score := result.Scores[i]
+		sources[i].RerankScore = &score
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		return *sources[i].RerankScore > *sources[j].RerankScore
+	})
+
+	return sources
+}
+
 // embedQueryText calls the python-ai embed HTTP endpoint to get a 1024-dim vector.
 func embedQueryText(query string) []float64 {
 	embedURL := getEnv("AI_EMBED_URL", "http://127.0.0.1:50052") + "/api/embed"
@@ -276,6 +452,31 @@ func embedQueryText(query string) []float64 {
 	return result.Vector
 }
 
+// embedQueryImage calls the python-ai CLIP embed HTTP endpoint to get a 512-dim
+// vector for text-to-image retrieval against visual_vector.
+func embedQueryImage(query string) []float64 {
+	embedURL := getEnv("AI_EMBED_URL", "http://127.0.0.1:50052") + "/api/embed_clip"
+
+	payload, _ := json.Marshal(map[string]string{"text": query})
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Post(embedURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return nil // fail silently, fallback to other retrievers
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil
+	}
+
+	var result struct {
+		Vector []float64 `json:"vector"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil
+	}
+	return result.Vector
+}
+
 // pgvectorSearch runs a cosine similarity query against asset_embeddings using pgvector.
 func pgvectorSearch(ctx context.Context, queryVector []float64, limit int) map[string]float64 {
 	scores := make(map[string]float64)
@@ -464,6 +665,18 @@ func parseFloatWithDefault(raw string, fallback float64) float64 {
 	return v
 }
 
+func getEnvBool(key string, fallback bool) bool {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
 func getEnv(key, fallback string) string {
 	value := strings.TrimSpace(os.Getenv(key))
 	if value == "" {
@@ -0,0 +1,63 @@
+package handler
+
+import "testing"
+
+func TestFuseRRF_OnlyVectorAvailable(t *testing.T) {
+	vectorScores := map[string]float64{"a": 0.9, "b": 0.5, "c": 0.1}
+	charScores := map[string]float64{} // character retriever returned nothing
+
+	fused := fuseRRF(60, vectorScores, charScores)
+
+	if fused["a"] <= fused["b"] || fused["b"] <= fused["c"] {
+		t.Fatalf("expected a > b > c by vector rank, got %+v", fused)
+	}
+	if got, want := fused["a"], 1.0/61.0; got != want {
+		t.Errorf("top vector-only hit: got %v, want %v", got, want)
+	}
+}
+
+func TestFuseRRF_OnlyCharacterAvailable(t *testing.T) {
+	vectorScores := map[string]float64{} // vector retriever returned nothing
+	charScores := map[string]float64{"a": 1.0, "b": 0.6, "c": 0.2}
+
+	fused := fuseRRF(60, vectorScores, charScores)
+
+	if fused["a"] <= fused["b"] || fused["b"] <= fused["c"] {
+		t.Fatalf("expected a > b > c by character rank, got %+v", fused)
+	}
+	if got, want := fused["a"], 1.0/61.0; got != want {
+		t.Errorf("top character-only hit: got %v, want %v", got, want)
+	}
+}
+
+func TestFuseRRF_BothAvailable(t *testing.T) {
+	// vector ranks: a=1 (0.95), b=2 (0.8), c=3 (0.3)
+	// character ranks: c=1 (1.0), b=2 (0.9), a=3 (0.1)
+	vectorScores := map[string]float64{"a": 0.95, "b": 0.8, "c": 0.3}
+	charScores := map[string]float64{"b": 0.9, "c": 1.0, "a": 0.1}
+
+	fused := fuseRRF(60, vectorScores, charScores)
+
+	wantA := 1.0/61.0 + 1.0/63.0
+	wantB := 1.0/62.0 + 1.0/62.0
+	wantC := 1.0/63.0 + 1.0/61.0
+
+	if fused["a"] != wantA {
+		t.Errorf("a: got %v, want %v", fused["a"], wantA)
+	}
+	if fused["b"] != wantB {
+		t.Errorf("b: got %v, want %v", fused["b"], wantB)
+	}
+	if fused["c"] != wantC {
+		t.Errorf("c: got %v, want %v", fused["c"], wantC)
+	}
+
+	// a and c each take one rank-1 and one rank-3 slot, so by the strict convexity
+	// of 1/x they tie and both edge out b, which is rank-2 in both lists.
+	if fused["a"] != fused["c"] {
+		t.Errorf("expected a and c (one rank-1, one rank-3 each) to tie, got a=%v c=%v", fused["a"], fused["c"])
+	}
+	if fused["a"] <= fused["b"] {
+		t.Errorf("expected a and c to beat b, got %+v", fused)
+	}
+}
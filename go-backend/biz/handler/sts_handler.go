@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	"thinkbank/backend/biz/pkg/errno"
+	"thinkbank/backend/biz/pkg/sts"
+)
+
+var stsProvider = sts.NewProvider(nil)
+
+// AssumeRoleResponse carries short-lived MinIO credentials scoped to the caller.
+type AssumeRoleResponse struct {
+	AccessKey    string `json:"accessKey"`
+	SecretKey    string `json:"secretKey"`
+	SessionToken string `json:"sessionToken"`
+	Expiration   string `json:"expiration"`
+}
+
+// AssumeRole exchanges the caller's bearer JWT for short-lived, per-user scoped MinIO
+// credentials (POST /sts/assume).
+func AssumeRole(ctx context.Context, c *app.RequestContext) {
+	authHeader := string(c.GetHeader("Authorization"))
+	token := strings.TrimPrefix(authHeader, "Bearer ")
+	if token == "" || token == authHeader {
+		c.JSON(consts.StatusBadRequest, errno.ParamErr)
+		return
+	}
+
+	creds, err := stsProvider.AssumeRole(ctx, token)
+	if err != nil {
+		c.JSON(consts.StatusUnauthorized, errno.Unauthorized)
+		return
+	}
+
+	c.JSON(consts.StatusOK, AssumeRoleResponse{
+		AccessKey:    creds.AccessKey,
+		SecretKey:    creds.SecretKey,
+		SessionToken: creds.SessionToken,
+		Expiration:   creds.Expiration.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
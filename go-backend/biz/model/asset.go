@@ -22,6 +22,10 @@ type Asset struct {
 	ContentText      string         `gorm:"type:text" json:"content_text"`
 	Metadata         JSONB          `gorm:"type:jsonb;default:'{}'" json:"metadata"`
 	ProcessingStatus string         `gorm:"type:varchar(32);default:'PENDING'" json:"processing_status"`
+	ContentHash      string         `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	Owner            string         `gorm:"type:varchar(128);index" json:"owner,omitempty"`
+	BlurHash         string         `gorm:"type:varchar(64)" json:"blur_hash,omitempty"`
+	ThumbnailObject  string         `gorm:"type:varchar(255)" json:"-"`
 	CreatedAt        time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt        time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UploadedPart records one completed part of a multipart upload.
+type UploadedPart struct {
+	PartNumber int    `json:"part_number"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
+}
+
+// UploadedParts is the JSONB-backed list of parts received so far.
+type UploadedParts []UploadedPart
+
+// AssetUpload tracks an in-progress multipart/resumable upload so a client can resume it after a network failure.
+type AssetUpload struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UploadID   string         `gorm:"type:varchar(255);not null;uniqueIndex" json:"upload_id"`
+	BucketName string         `gorm:"type:varchar(64);not null" json:"bucket_name"`
+	ObjectName string         `gorm:"type:varchar(255);not null" json:"object_name"`
+	MimeType   string         `gorm:"type:varchar(127);not null" json:"mime_type"`
+	Owner      string         `gorm:"type:varchar(128);index" json:"owner,omitempty"`
+	TotalSize  int64          `gorm:"not null" json:"total_size"`
+	Received   int64          `gorm:"not null;default:0" json:"received"`
+	Parts      UploadedParts  `gorm:"type:jsonb;default:'[]'" json:"parts"`
+	Status     string         `gorm:"type:varchar(32);default:'PENDING'" json:"status"`
+	AssetID    *uuid.UUID     `gorm:"type:uuid" json:"asset_id,omitempty"`
+	ExpiresAt  time.Time      `gorm:"not null" json:"expires_at"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (AssetUpload) TableName() string {
+	return "asset_uploads"
+}
+
+// BeforeCreate sets UUID before creating
+func (u *AssetUpload) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return nil
+}
+
+// Value implements driver.Valuer for PostgreSQL JSONB serialization.
+func (p UploadedParts) Value() (driver.Value, error) {
+	if p == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner for PostgreSQL JSONB deserialization.
+func (p *UploadedParts) Scan(value interface{}) error {
+	if p == nil {
+		return fmt.Errorf("UploadedParts scan target is nil")
+	}
+
+	switch v := value.(type) {
+	case nil:
+		*p = UploadedParts{}
+		return nil
+	case []byte:
+		if len(v) == 0 {
+			*p = UploadedParts{}
+			return nil
+		}
+		return json.Unmarshal(v, p)
+	case string:
+		if v == "" {
+			*p = UploadedParts{}
+			return nil
+		}
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("unsupported UploadedParts Scan type: %T", value)
+	}
+}
+
+// Upload status constants
+const (
+	UploadStatusPending   = "PENDING"
+	UploadStatusCompleted = "COMPLETED"
+	UploadStatusAborted   = "ABORTED"
+)
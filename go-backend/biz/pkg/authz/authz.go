@@ -0,0 +1,153 @@
+// Package authz decouples asset authorization from Go code by delegating allow/deny
+// decisions to an external policy decision point (default: Open Policy Agent).
+package authz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Subject identifies the caller making the request.
+type Subject struct {
+	ID string `json:"id"`
+}
+
+// Resource describes the asset being acted on.
+type Resource struct {
+	AssetID  string                 `json:"asset_id"`
+	Bucket   string                 `json:"bucket"`
+	Object   string                 `json:"object"`
+	MimeType string                 `json:"mime_type"`
+	Metadata map[string]interface{} `json:"metadata"`
+	Owner    string                 `json:"owner"`
+}
+
+// Input is the JSON document sent to the policy decision point.
+type Input struct {
+	Subject  Subject                `json:"subject"`
+	Action   string                 `json:"action"`
+	Resource Resource               `json:"resource"`
+	Context  map[string]interface{} `json:"context"`
+}
+
+// Decision is the verdict returned by an Engine.
+type Decision struct {
+	Allow  bool   `json:"allow"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Engine evaluates an authorization Input and returns a Decision.
+type Engine interface {
+	Evaluate(ctx context.Context, input Input) (Decision, error)
+}
+
+// NoopEngine allows everything; useful for local dev or when authz is not yet configured.
+type NoopEngine struct{}
+
+func (NoopEngine) Evaluate(ctx context.Context, input Input) (Decision, error) {
+	return Decision{Allow: true}, nil
+}
+
+// OPAEngine evaluates decisions against a remote Open Policy Agent instance over HTTP.
+type OPAEngine struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewOPAEngine builds an OPAEngine pointed at the given OPA data API URL
+// (e.g. http://localhost:8181/v1/data/thinkbank/authz/allow).
+func NewOPAEngine(url string) *OPAEngine {
+	return &OPAEngine{
+		URL:    url,
+		Client: &http.Client{Timeout: 2 * time.Second},
+	}
+}
+
+func (e *OPAEngine) Evaluate(ctx context.Context, input Input) (Decision, error) {
+	body, err := json.Marshal(map[string]Input{"input": input})
+	if err != nil {
+		return Decision{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.Client.Do(req)
+	if err != nil {
+		return Decision{}, fmt.Errorf("opa request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed struct {
+		Result bool `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Decision{}, fmt.Errorf("failed to decode opa response: %w", err)
+	}
+
+	return Decision{Allow: parsed.Result}, nil
+}
+
+// cachedEngine wraps an Engine with a TTL cache keyed on (subject, action, resource),
+// so repeat decisions for the same request shape don't round-trip to the PDP every time.
+type cachedEngine struct {
+	inner Engine
+	ttl   time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	decision Decision
+	expires  time.Time
+}
+
+// WithCache wraps an Engine with a decision cache of the given TTL.
+func WithCache(inner Engine, ttl time.Duration) Engine {
+	return &cachedEngine{inner: inner, ttl: ttl, cache: make(map[string]cacheEntry)}
+}
+
+func (c *cachedEngine) Evaluate(ctx context.Context, input Input) (Decision, error) {
+	key := cacheKey(input)
+
+	c.mu.Lock()
+	if entry, ok := c.cache[key]; ok && time.Now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.decision, nil
+	}
+	c.mu.Unlock()
+
+	decision, err := c.inner.Evaluate(ctx, input)
+	if err != nil {
+		return decision, err
+	}
+
+	c.mu.Lock()
+	c.cache[key] = cacheEntry{decision: decision, expires: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return decision, nil
+}
+
+func cacheKey(input Input) string {
+	return input.Subject.ID + "|" + input.Action + "|" + input.Resource.AssetID
+}
+
+// DefaultEngine builds the configured Engine from environment variables:
+// OPA_URL (if set) wrapped in a 30s decision cache, otherwise NoopEngine.
+func DefaultEngine() Engine {
+	if url := os.Getenv("OPA_URL"); url != "" {
+		return WithCache(NewOPAEngine(url), 30*time.Second)
+	}
+	return NoopEngine{}
+}
@@ -0,0 +1,50 @@
+package authz
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	"thinkbank/backend/biz/dal/postgres"
+	"thinkbank/backend/biz/model"
+	"thinkbank/backend/biz/pkg/errno"
+)
+
+// Middleware resolves the asset named by the ":id" path param and asks engine whether
+// subject (the caller's user ID, threaded in via the "X-User-ID" header for now) may
+// perform action on it. Deny decisions short-circuit the request with errno.Forbidden.
+func Middleware(engine Engine, action string) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		assetID := c.Param("id")
+
+		var asset model.Asset
+		if err := postgres.DB.WithContext(ctx).Where("id = ?", assetID).First(&asset).Error; err != nil {
+			c.JSON(consts.StatusNotFound, errno.AssetNotFound)
+			c.Abort()
+			return
+		}
+
+		input := Input{
+			Subject: Subject{ID: string(c.GetHeader("X-User-ID"))},
+			Action:  action,
+			Resource: Resource{
+				AssetID:  asset.ID.String(),
+				Bucket:   asset.BucketName,
+				Object:   asset.ObjectName,
+				MimeType: asset.MimeType,
+				Metadata: asset.Metadata,
+				Owner:    asset.Owner,
+			},
+		}
+
+		decision, err := engine.Evaluate(ctx, input)
+		if err != nil || !decision.Allow {
+			c.JSON(consts.StatusForbidden, errno.Forbidden)
+			c.Abort()
+			return
+		}
+
+		c.Next(ctx)
+	}
+}
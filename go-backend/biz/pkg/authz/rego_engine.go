@@ -0,0 +1,40 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// LocalRegoEngine evaluates decisions against an embedded Rego policy, so policy
+// iteration doesn't require running (or redeploying with) a separate OPA process.
+type LocalRegoEngine struct {
+	query rego.PreparedEvalQuery
+}
+
+// NewLocalRegoEngine compiles the given Rego module (expected to define `data.<query>`,
+// e.g. "thinkbank.authz.allow") for repeated evaluation.
+func NewLocalRegoEngine(ctx context.Context, query, module string) (*LocalRegoEngine, error) {
+	prepared, err := rego.New(
+		rego.Query("data."+query),
+		rego.Module("policy.rego", module),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile rego policy: %w", err)
+	}
+	return &LocalRegoEngine{query: prepared}, nil
+}
+
+func (e *LocalRegoEngine) Evaluate(ctx context.Context, input Input) (Decision, error) {
+	results, err := e.query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return Decision{}, fmt.Errorf("rego evaluation failed: %w", err)
+	}
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return Decision{Allow: false, Reason: "no rego result"}, nil
+	}
+
+	allow, _ := results[0].Expressions[0].Value.(bool)
+	return Decision{Allow: allow}, nil
+}
@@ -39,6 +39,8 @@ var (
 	AssetNotFound   = New(consts.StatusNotFound, 20001, "asset not found")
 	InvalidFileType = New(consts.StatusBadRequest, 20002, "invalid file type")
 	FileTooLarge    = New(consts.StatusBadRequest, 20003, "file too large")
+	Unauthorized    = New(consts.StatusUnauthorized, 10009, "unauthorized")
+	Forbidden       = New(consts.StatusForbidden, 10008, "forbidden")
 )
 
 // ConvertErr converts error to ErrNo
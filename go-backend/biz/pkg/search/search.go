@@ -0,0 +1,236 @@
+// Package search runs semantic, visual, and full-text retrieval over assets in parallel
+// and fuses their rankings with Reciprocal Rank Fusion, so exact caption/filename matches
+// aren't drowned out by cosine-similarity-only ranking.
+package search
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"thinkbank/backend/biz/dal/postgres"
+)
+
+// DefaultK is the RRF smoothing constant: score(a) = Σ 1/(k + rank_i(a)).
+const DefaultK = 60
+
+// Modality selects which retrievers participate in a Search call.
+type Modality string
+
+const (
+	ModalityText  Modality = "text"
+	ModalityImage Modality = "image"
+)
+
+// Filters narrows the candidate set before retrieval.
+type Filters struct {
+	MimeTypePrefix   string
+	ProcessingStatus string
+}
+
+// Hit is a single fused search result.
+type Hit struct {
+	AssetID     string             `json:"asset_id"`
+	Score       float64            `json:"score"`
+	DebugScores map[string]float64 `json:"debug_scores"`
+}
+
+// EmbedFunc embeds query text into a vector for a given retriever (semantic/visual
+// encoders differ, so callers pass the right one in).
+type EmbedFunc func(ctx context.Context, query string) []float64
+
+// Search runs the configured retrievers in parallel and returns the top-N fused hits.
+func Search(ctx context.Context, query string, modalities []Modality, topK int, filters Filters, embedSemantic, embedVisual EmbedFunc) ([]Hit, error) {
+	if topK <= 0 {
+		topK = 20
+	}
+
+	wantText := containsModality(modalities, ModalityText) || len(modalities) == 0
+	wantImage := containsModality(modalities, ModalityImage)
+
+	var wg sync.WaitGroup
+	var semanticRanked, visualRanked, textRanked []string
+	var mu sync.Mutex
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if !wantText || embedSemantic == nil {
+			return
+		}
+		vec := embedSemantic(ctx, query)
+		if vec == nil {
+			return
+		}
+		ranked := rankByVector(ctx, "semantic_vector", vec, filters, 200)
+		mu.Lock()
+		semanticRanked = ranked
+		mu.Unlock()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if !wantImage || embedVisual == nil {
+			return
+		}
+		vec := embedVisual(ctx, query)
+		if vec == nil {
+			return
+		}
+		ranked := rankByVector(ctx, "visual_vector", vec, filters, 200)
+		mu.Lock()
+		visualRanked = ranked
+		mu.Unlock()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if strings.TrimSpace(query) == "" {
+			return
+		}
+		ranked := rankByFullText(ctx, query, filters, 200)
+		mu.Lock()
+		textRanked = ranked
+		mu.Unlock()
+	}()
+
+	wg.Wait()
+
+	lists := map[string][]string{
+		"semantic":  semanticRanked,
+		"visual":    visualRanked,
+		"full_text": textRanked,
+	}
+	fused := Fuse(lists, DefaultK)
+
+	if len(fused) > topK {
+		fused = fused[:topK]
+	}
+	return fused, nil
+}
+
+// Fuse combines named, independently-ranked ID lists via Reciprocal Rank Fusion:
+// score(a) = Σ_i 1/(k + rank_i(a)), where rank_i is the 1-based position of a in
+// list i (missing from a list contributes 0), sorted descending by fused score.
+func Fuse(lists map[string][]string, k int) []Hit {
+	scores := make(map[string]float64)
+	debug := make(map[string]map[string]float64)
+
+	for name, ranked := range lists {
+		for i, id := range ranked {
+			rank := i + 1
+			contribution := 1.0 / float64(k+rank)
+			scores[id] += contribution
+			if debug[id] == nil {
+				debug[id] = make(map[string]float64)
+			}
+			debug[id][name] = contribution
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{AssetID: id, Score: score, DebugScores: debug[id]})
+	}
+
+	sortHitsDescending(hits)
+	return hits
+}
+
+func containsModality(modalities []Modality, m Modality) bool {
+	for _, v := range modalities {
+		if v == m {
+			return true
+		}
+	}
+	return false
+}
+
+func rankByVector(ctx context.Context, column string, vec []float64, filters Filters, limit int) []string {
+	vecParts := make([]string, len(vec))
+	for i, v := range vec {
+		vecParts[i] = strconv.FormatFloat(v, 'f', 8, 64)
+	}
+	vecStr := "[" + strings.Join(vecParts, ",") + "]"
+
+	clause, clauseArgs := filterClause(filters, "a")
+	query := `
+		SELECT ae.asset_id::text AS id
+		FROM asset_embeddings ae
+		JOIN assets a ON a.id = ae.asset_id
+		WHERE ae.` + column + ` IS NOT NULL ` + clause + `
+		ORDER BY ae.` + column + ` <=> ?::vector ASC
+		LIMIT ?`
+
+	args := append([]interface{}{}, clauseArgs...)
+	args = append(args, vecStr, limit)
+
+	var rows []struct {
+		ID string `gorm:"column:id"`
+	}
+	if err := postgres.DB.WithContext(ctx).Raw(query, args...).Scan(&rows).Error; err != nil {
+		return nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func rankByFullText(ctx context.Context, query string, filters Filters, limit int) []string {
+	clause, clauseArgs := filterClause(filters, "a")
+	sql := `
+		SELECT id::text AS id
+		FROM assets a
+		WHERE search_tsv @@ plainto_tsquery('simple', ?) ` + clause + `
+		ORDER BY ts_rank(search_tsv, plainto_tsquery('simple', ?)) DESC
+		LIMIT ?`
+
+	args := append([]interface{}{query}, clauseArgs...)
+	args = append(args, query, limit)
+
+	var rows []struct {
+		ID string `gorm:"column:id"`
+	}
+	if err := postgres.DB.WithContext(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+// filterClause builds a parameterized WHERE clause fragment (args must be appended
+// before the trailing positional parameters in the caller's query).
+func filterClause(filters Filters, alias string) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+	if filters.MimeTypePrefix != "" {
+		clauses = append(clauses, alias+".mime_type LIKE ?")
+		args = append(args, filters.MimeTypePrefix+"%")
+	}
+	if filters.ProcessingStatus != "" {
+		clauses = append(clauses, alias+".processing_status = ?")
+		args = append(args, filters.ProcessingStatus)
+	}
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return "AND " + strings.Join(clauses, " AND "), args
+}
+
+func sortHitsDescending(hits []Hit) {
+	for i := 1; i < len(hits); i++ {
+		for j := i; j > 0 && hits[j].Score > hits[j-1].Score; j-- {
+			hits[j], hits[j-1] = hits[j-1], hits[j]
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Package secrets loads database/MinIO/LLM credentials from an encrypted config blob
+// instead of plaintext environment variables, decrypting them in memory only for the
+// lifetime of the process.
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Config holds the decrypted secrets consumed by the dal packages.
+type Config struct {
+	DBPassword    string `json:"db_password"`
+	MinIOPassword string `json:"minio_password"`
+	RedisPassword string `json:"redis_password"`
+	LLMAPIKey     string `json:"llm_api_key"`
+}
+
+// Store wraps a decrypted Config and clears its fields on Close.
+type Store struct {
+	cfg Config
+}
+
+// KeyProvider resolves the passphrase (or raw key) used to decrypt config.enc.
+type KeyProvider interface {
+	Key(ctx context.Context) ([]byte, error)
+}
+
+// EnvProvider reads the passphrase directly from an environment variable.
+type EnvProvider struct {
+	EnvVar string
+}
+
+func (p EnvProvider) Key(ctx context.Context) ([]byte, error) {
+	value := os.Getenv(p.EnvVar)
+	if value == "" {
+		return nil, fmt.Errorf("env var %s is not set", p.EnvVar)
+	}
+	return []byte(value), nil
+}
+
+// FileProvider reads the passphrase from a file on disk (e.g. a mounted k8s secret).
+type FileProvider struct {
+	Path string
+}
+
+func (p FileProvider) Key(ctx context.Context) ([]byte, error) {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %s: %w", p.Path, err)
+	}
+	return bytesTrimNewline(data), nil
+}
+
+// VaultProvider fetches the passphrase from a HashiCorp Vault KV v2 secret.
+type VaultProvider struct {
+	Addr       string
+	Token      string
+	SecretPath string // e.g. "secret/data/thinkbank/master-key"
+	Field      string // key within the secret's data map, default "key"
+}
+
+func (p VaultProvider) Key(ctx context.Context) ([]byte, error) {
+	field := p.Field
+	if field == "" {
+		field = "key"
+	}
+	value, err := fetchVaultField(ctx, p.Addr, p.Token, p.SecretPath, field)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(value), nil
+}
+
+func bytesTrimNewline(b []byte) []byte {
+	for len(b) > 0 && (b[len(b)-1] == '\n' || b[len(b)-1] == '\r') {
+		b = b[:len(b)-1]
+	}
+	return b
+}
+
+// encryptedBlob is the on-disk format of config.enc.
+type encryptedBlob struct {
+	Salt       string `json:"salt"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+const (
+	argonTime    = 1
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+	argonKeyLen  = 32
+)
+
+// Load reads and decrypts the config blob at path (THINKBANK_CONFIG) using provider
+// to resolve the passphrase. If path is empty, Load returns (nil, nil) so callers can
+// fall back to plaintext env vars.
+func Load(ctx context.Context, path string, provider KeyProvider) (*Store, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encrypted config %s: %w", path, err)
+	}
+
+	var blob encryptedBlob
+	if err := json.Unmarshal(raw, &blob); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted config: %w", err)
+	}
+
+	passphrase, err := provider.Key(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve master key: %w", err)
+	}
+	defer zero(passphrase)
+
+	plaintext, err := decrypt(blob, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(plaintext)
+
+	var cfg Config
+	if err := json.Unmarshal(plaintext, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode decrypted config: %w", err)
+	}
+
+	return &Store{cfg: cfg}, nil
+}
+
+// Encrypt derives a key from passphrase via Argon2id and encrypts cfg into the
+// on-disk blob format consumed by Load. Used by the thinkbank-config encrypt CLI.
+func Encrypt(cfg Config, passphrase []byte) ([]byte, error) {
+	salt := make([]byte, 16)
+	if _, err := randRead(salt); err != nil {
+		return nil, err
+	}
+
+	key := deriveKey(passphrase, salt)
+	defer zero(key)
+
+	plaintext, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := randRead(nonce); err != nil {
+		return nil, err
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	blob := encryptedBlob{
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}
+	return json.MarshalIndent(blob, "", "  ")
+}
+
+func decrypt(blob encryptedBlob, passphrase []byte) ([]byte, error) {
+	salt, err := base64.StdEncoding.DecodeString(blob.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt encoding: %w", err)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(blob.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce encoding: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(blob.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext encoding: %w", err)
+	}
+
+	key := deriveKey(passphrase, salt)
+	defer zero(key)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt config (wrong key?): %w", err)
+	}
+	return plaintext, nil
+}
+
+func deriveKey(passphrase, salt []byte) []byte {
+	return argon2.IDKey(passphrase, salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// DBPassword returns the decrypted database password.
+func (s *Store) DBPassword() string { return s.cfg.DBPassword }
+
+// MinIOPassword returns the decrypted MinIO password.
+func (s *Store) MinIOPassword() string { return s.cfg.MinIOPassword }
+
+// RedisPassword returns the decrypted Redis password.
+func (s *Store) RedisPassword() string { return s.cfg.RedisPassword }
+
+// LLMAPIKey returns the decrypted LLM API key.
+func (s *Store) LLMAPIKey() string { return s.cfg.LLMAPIKey }
+
+// Close drops the Store's references to the decrypted secrets by setting each
+// field to "". The memory that held the secret strings is not overwritten; it
+// stays allocated until the garbage collector reclaims it.
+func (s *Store) Close() error {
+	s.cfg.DBPassword = ""
+	s.cfg.MinIOPassword = ""
+	s.cfg.RedisPassword = ""
+	s.cfg.LLMAPIKey = ""
+	return nil
+}
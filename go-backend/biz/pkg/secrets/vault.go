@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func randRead(b []byte) (int, error) {
+	return rand.Read(b)
+}
+
+// fetchVaultField reads a single field from a Vault KV v2 secret's data map.
+func fetchVaultField(ctx context.Context, addr, token, secretPath, field string) (string, error) {
+	url := strings.TrimRight(addr, "/") + "/v1/" + strings.TrimLeft(secretPath, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	value, ok := parsed.Data.Data[field]
+	if !ok {
+		return "", fmt.Errorf("vault secret %s missing field %q", secretPath, field)
+	}
+	return value, nil
+}
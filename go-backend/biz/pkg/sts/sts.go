@@ -0,0 +1,268 @@
+// Package sts exchanges a caller JWT for short-lived, per-user scoped MinIO credentials,
+// so asset downloads/uploads can go straight from the browser to MinIO instead of proxying
+// through the Hertz process with the MinIO root credentials.
+package sts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type Config struct {
+	JWKSURL         string
+	STSEndpoint     string
+	DurationSeconds int
+	ResourceArn     string // template; ${sub} is substituted with the caller's subject
+	JWKSCacheTTL    time.Duration
+}
+
+// DefaultConfig returns configuration from environment variables
+func DefaultConfig() *Config {
+	return &Config{
+		JWKSURL:         getEnv("STS_JWKS_URL", "http://127.0.0.1:8080/.well-known/jwks.json"),
+		STSEndpoint:     getEnv("STS_MINIO_ENDPOINT", "http://127.0.0.1:9000"),
+		DurationSeconds: getEnvInt("STS_DURATION_SECONDS", 3600),
+		ResourceArn:     getEnv("STS_RESOURCE_ARN", "arn:aws:s3:::thinkbank-assets/users/${sub}/*"),
+		JWKSCacheTTL:    5 * time.Minute,
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// Credentials are the short-lived credentials handed back to the caller.
+type Credentials struct {
+	AccessKey    string    `json:"accessKey"`
+	SecretKey    string    `json:"secretKey"`
+	SessionToken string    `json:"sessionToken"`
+	Expiration   time.Time `json:"expiration"`
+}
+
+// Provider validates caller JWTs against a JWKS endpoint and exchanges them for scoped MinIO credentials.
+type Provider struct {
+	cfg *Config
+
+	mu     sync.RWMutex
+	keys   map[string]interface{} // kid -> *rsa.PublicKey | *ecdsa.PublicKey
+	keysAt time.Time
+}
+
+// NewProvider builds a Provider from the given config (or DefaultConfig if nil).
+func NewProvider(cfg *Config) *Provider {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	return &Provider{cfg: cfg}
+}
+
+// AssumeRole validates the caller JWT (RSA or ECDSA, via JWKS) and exchanges it for
+// per-user scoped MinIO credentials using AssumeRoleWithWebIdentity.
+func (p *Provider) AssumeRole(ctx context.Context, rawToken string) (*Credentials, error) {
+	sub, err := p.validate(ctx, rawToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	policy := p.renderPolicy(sub)
+
+	sts, err := credentials.NewSTSWebIdentity(p.cfg.STSEndpoint, func() (*credentials.WebIdentityToken, error) {
+		return &credentials.WebIdentityToken{Token: rawToken, Expiry: p.cfg.DurationSeconds}, nil
+	}, credentials.WithPolicy(policy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build STS web identity provider: %w", err)
+	}
+
+	value, err := sts.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to assume role: %w", err)
+	}
+
+	return &Credentials{
+		AccessKey:    value.AccessKeyID,
+		SecretKey:    value.SecretAccessKey,
+		SessionToken: value.SessionToken,
+		Expiration:   value.Expiration,
+	}, nil
+}
+
+// renderPolicy substitutes ${sub} in the configured resource ARN template.
+func (p *Provider) renderPolicy(sub string) string {
+	arn := strings.ReplaceAll(p.cfg.ResourceArn, "${sub}", sub)
+	return `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["` + arn + `"]}
+		]
+	}`
+}
+
+// validate checks the JWT signature against the configured JWKS (RSA or ECDSA keys)
+// and returns the subject claim.
+func (p *Provider) validate(ctx context.Context, rawToken string) (string, error) {
+	keySet, err := p.loadJWKS(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(rawToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := keySet[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		switch key.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey:
+			return key, nil
+		default:
+			return nil, fmt.Errorf("unsupported key type for kid %q", kid)
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return "", fmt.Errorf("token missing sub claim")
+	}
+	return sub, nil
+}
+
+type jwksDoc struct {
+	Keys []jwksKey `json:"keys"`
+}
+
+type jwksKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// loadJWKS fetches and parses the JWKS document, caching it for JWKSCacheTTL.
+func (p *Provider) loadJWKS(ctx context.Context) (map[string]interface{}, error) {
+	p.mu.RLock()
+	if p.keys != nil && time.Since(p.keysAt) < p.cfg.JWKSCacheTTL {
+		keys := p.keys
+		p.mu.RUnlock()
+		return keys, nil
+	}
+	p.mu.RUnlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var doc jwksDoc
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(doc.Keys))
+	for _, k := range doc.Keys {
+		pub, err := parseJWK(k)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.keysAt = time.Now()
+	p.mu.Unlock()
+
+	return keys, nil
+}
+
+func parseJWK(k jwksKey) (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		eBuf := make([]byte, 8)
+		copy(eBuf[8-len(eBytes):], eBytes)
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(nBytes),
+			E: int(binary.BigEndian.Uint64(eBuf)),
+		}, nil
+	case "EC":
+		xBytes, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		yBytes, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC curve %q", k.Crv)
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(xBytes),
+			Y:     new(big.Int).SetBytes(yBytes),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
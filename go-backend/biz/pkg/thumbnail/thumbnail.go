@@ -0,0 +1,127 @@
+// Package thumbnail generates BlurHash placeholders and WebP thumbnails for
+// image assets, called synchronously from the upload handler against the
+// upload's own temp file so it doesn't have to re-download what it just
+// uploaded to MinIO.
+package thumbnail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+
+	"github.com/buckket/go-blurhash"
+	"github.com/chai2010/webp"
+	"golang.org/x/image/draw"
+
+	"thinkbank/backend/biz/dal/minio"
+)
+
+// MaxDownloadBytes caps how much of a source image the pipeline will read before
+// generating a thumbnail, independent of Upload's 100MB whole-file cap.
+const MaxDownloadBytes = 5 * 1024 * 1024
+
+// MaxEdge is the longest edge, in pixels, of a generated thumbnail.
+const MaxEdge = 512
+
+// BlurHash component counts per https://github.com/woltapp/blurhash.
+const (
+	blurHashComponentsX = 4
+	blurHashComponentsY = 3
+)
+
+// ErrTooLarge is returned when the source image exceeds MaxDownloadBytes.
+var ErrTooLarge = fmt.Errorf("thumbnail: image exceeds %d byte cap", MaxDownloadBytes)
+
+// Result holds the generated placeholder and thumbnail for an image asset.
+type Result struct {
+	BlurHash  string
+	Thumbnail []byte // WebP-encoded
+}
+
+// Generate decodes r as an image, capped at MaxDownloadBytes, and produces a
+// BlurHash placeholder plus a WebP thumbnail no larger than MaxEdge on its
+// longest edge.
+func Generate(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxDownloadBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("thumbnail: read source: %w", err)
+	}
+	if len(data) > MaxDownloadBytes {
+		return nil, ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("thumbnail: decode image: %w", err)
+	}
+
+	hash, err := blurhash.Encode(blurHashComponentsX, blurHashComponentsY, img)
+	if err != nil {
+		return nil, fmt.Errorf("thumbnail: encode blurhash: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := webp.Encode(&buf, resize(img, MaxEdge), &webp.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("thumbnail: encode webp: %w", err)
+	}
+
+	return &Result{BlurHash: hash, Thumbnail: buf.Bytes()}, nil
+}
+
+// ObjectName returns the conventional storage key for an asset's thumbnail.
+func ObjectName(assetID string) string {
+	return assetID + "/thumb.webp"
+}
+
+// Process generates an image asset's BlurHash and WebP thumbnail from r — the
+// asset's own bytes, read from wherever the caller already has them open, rather
+// than downloading a copy back from MinIO — uploads the thumbnail to MinIO, and
+// returns the BlurHash plus the thumbnail's object name for the caller to persist
+// on the asset row.
+func Process(ctx context.Context, r io.Reader, bucket, assetID string) (blurHash, thumbnailObject string, err error) {
+	result, err := Generate(r)
+	if err != nil {
+		return "", "", err
+	}
+
+	thumbnailObject = ObjectName(assetID)
+	if err := minio.UploadFromReader(ctx, bucket, thumbnailObject, bytes.NewReader(result.Thumbnail), int64(len(result.Thumbnail)), "image/webp"); err != nil {
+		return "", "", fmt.Errorf("thumbnail: upload thumbnail: %w", err)
+	}
+
+	return result.BlurHash, thumbnailObject, nil
+}
+
+// resize scales img so its longest edge is maxEdge, preserving aspect ratio.
+// Images already within bounds are returned unchanged.
+func resize(img image.Image, maxEdge int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxEdge && h <= maxEdge {
+		return img
+	}
+
+	var newW, newH int
+	if w >= h {
+		newW = maxEdge
+		newH = int(float64(h) * float64(maxEdge) / float64(w))
+	} else {
+		newH = maxEdge
+		newW = int(float64(w) * float64(maxEdge) / float64(h))
+	}
+	if newW < 1 {
+		newW = 1
+	}
+	if newH < 1 {
+		newH = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, newW, newH))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
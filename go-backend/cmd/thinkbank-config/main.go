@@ -0,0 +1,67 @@
+// Command thinkbank-config manages the encrypted config.enc blob consumed by pkg/secrets.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"thinkbank/backend/biz/pkg/secrets"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "encrypt" {
+		fmt.Fprintln(os.Stderr, "usage: thinkbank-config encrypt -in secrets.yaml -out config.enc")
+		os.Exit(1)
+	}
+
+	if err := runEncrypt(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func runEncrypt(args []string) error {
+	var inPath, outPath string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-in":
+			i++
+			inPath = args[i]
+		case "-out":
+			i++
+			outPath = args[i]
+		}
+	}
+	if inPath == "" || outPath == "" {
+		return fmt.Errorf("both -in and -out are required")
+	}
+
+	raw, err := os.ReadFile(inPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", inPath, err)
+	}
+
+	var cfg secrets.Config
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", inPath, err)
+	}
+
+	passphrase := []byte(os.Getenv("THINKBANK_MASTER_KEY"))
+	if len(passphrase) == 0 {
+		return fmt.Errorf("THINKBANK_MASTER_KEY must be set to the encryption passphrase")
+	}
+
+	blob, err := secrets.Encrypt(cfg, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt config: %w", err)
+	}
+
+	if err := os.WriteFile(outPath, blob, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+
+	fmt.Printf("wrote encrypted config to %s\n", outPath)
+	return nil
+}